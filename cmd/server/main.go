@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 	"net/http"
@@ -9,6 +10,8 @@ import (
 
 	"nuzlocke/internal/api"
 	"nuzlocke/internal/data"
+	"nuzlocke/internal/live"
+	"nuzlocke/internal/runs"
 )
 
 func main() {
@@ -16,6 +19,8 @@ func main() {
 	port := flag.String("port", "8080", "Server port")
 	dataDir := flag.String("data", "data", "Data directory containing JSON files")
 	webDir := flag.String("web", "web", "Web directory containing static files")
+	runsDir := flag.String("runs", "runs", "Directory where Nuzlocke runs are saved")
+	watchPath := flag.String("watch", "", "Save file to watch for live updates (e.g. path/to/game.sav)")
 	flag.Parse()
 
 	// Get absolute paths
@@ -26,6 +31,10 @@ func main() {
 
 	absDataDir := filepath.Join(workDir, *dataDir)
 	absWebDir := filepath.Join(workDir, *webDir)
+	absRunsDir := *runsDir
+	if !filepath.IsAbs(absRunsDir) {
+		absRunsDir = filepath.Join(workDir, absRunsDir)
+	}
 
 	// Load data
 	log.Printf("Loading data from %s...", absDataDir)
@@ -42,8 +51,24 @@ func main() {
 		len(store.Learnsets),
 	)
 
+	// Open run storage
+	runStore, err := runs.NewStore(absRunsDir)
+	if err != nil {
+		log.Fatal("Failed to open runs directory:", err)
+	}
+	log.Printf("Saving runs to %s", runStore.Dir())
+
 	// Create handler
 	handler := api.NewHandler(store)
+	handler.Runs = runStore
+
+	// Watch a save file and push changes to /api/live/events
+	if *watchPath != "" {
+		handler.Live = live.NewBroker()
+		watcher := live.NewWatcher(*watchPath, handler.PublishSave)
+		go watcher.Run(context.Background())
+		log.Printf("Watching %s for save changes", *watchPath)
+	}
 
 	// Setup routes
 	mux := http.NewServeMux()
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"nuzlocke/internal/data"
+)
+
+// defaultEncounterGame is used when no ?game= is given
+const defaultEncounterGame = "emerald"
+
+// EncounterMethodResponse is one encounter method's table at a location
+type EncounterMethodResponse struct {
+	Method  string               `json:"method"`
+	Slots   []data.EncounterSlot `json:"slots"`   // Slots as listed in the data file
+	Species []data.EncounterSlot `json:"species"` // Slots combined per species
+}
+
+// LocationEncountersResponse is the response for GET /api/encounters/{location}
+type LocationEncountersResponse struct {
+	Game     string                    `json:"game"`
+	Location string                    `json:"location"`
+	Name     string                    `json:"name"`
+	Methods  []EncounterMethodResponse `json:"methods"`
+}
+
+// LocationSummary is a location in the encounter list
+type LocationSummary struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Methods []string `json:"methods"`
+}
+
+// PokemonLocationsResponse is the response for GET /api/pokemon/{id}/locations
+type PokemonLocationsResponse struct {
+	Pokemon   string                 `json:"pokemon"`
+	Game      string                 `json:"game"`
+	Locations []data.PokemonLocation `json:"locations"`
+}
+
+// encounterGame returns the game from the ?game= query param
+func encounterGame(r *http.Request) string {
+	if game := r.URL.Query().Get("game"); game != "" {
+		return data.ToID(game)
+	}
+	return defaultEncounterGame
+}
+
+// routeEncounters routes encounter requests based on path
+func (h *Handler) routeEncounters(w http.ResponseWriter, r *http.Request) {
+	location := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/encounters"), "/")
+	if location == "" {
+		h.HandleListEncounterLocations(w, r)
+		return
+	}
+	h.HandleGetEncounters(w, r, location)
+}
+
+// HandleListEncounterLocations handles GET /api/encounters
+func (h *Handler) HandleListEncounterLocations(w http.ResponseWriter, r *http.Request) {
+	game := encounterGame(r)
+	table := h.Store.GetEncounterTable(game)
+	if table == nil {
+		http.Error(w, "No encounter data for game: "+game, http.StatusNotFound)
+		return
+	}
+
+	locations := make([]LocationSummary, 0, len(table.Locations))
+	for _, loc := range table.Locations {
+		summary := LocationSummary{ID: loc.ID, Name: loc.Name, Methods: []string{}}
+		for _, method := range data.EncounterMethods {
+			if len(loc.Slots(method)) > 0 {
+				summary.Methods = append(summary.Methods, method)
+			}
+		}
+		locations = append(locations, summary)
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].Name < locations[j].Name
+	})
+
+	writeJSON(w, http.StatusOK, locations)
+}
+
+// HandleGetEncounters handles GET /api/encounters/{location}
+func (h *Handler) HandleGetEncounters(w http.ResponseWriter, r *http.Request, location string) {
+	game := encounterGame(r)
+	if h.Store.GetEncounterTable(game) == nil {
+		http.Error(w, "No encounter data for game: "+game, http.StatusNotFound)
+		return
+	}
+
+	loc := h.Store.GetLocationEncounters(game, location)
+	if loc == nil {
+		http.Error(w, "Location not found", http.StatusNotFound)
+		return
+	}
+
+	response := LocationEncountersResponse{
+		Game:     game,
+		Location: loc.ID,
+		Name:     loc.Name,
+		Methods:  []EncounterMethodResponse{},
+	}
+	for _, method := range data.EncounterMethods {
+		slots := loc.Slots(method)
+		if len(slots) == 0 {
+			continue
+		}
+		response.Methods = append(response.Methods, EncounterMethodResponse{
+			Method:  method,
+			Slots:   slots,
+			Species: data.CombineSlots(slots),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// HandleGetPokemonLocations handles GET /api/pokemon/{id}/locations
+func (h *Handler) HandleGetPokemonLocations(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/pokemon/")
+	id = strings.TrimSuffix(id, "/locations")
+
+	pokemon := h.Store.GetPokemon(id)
+	if pokemon == nil {
+		http.Error(w, "Pokemon not found", http.StatusNotFound)
+		return
+	}
+
+	game := encounterGame(r)
+	if h.Store.GetEncounterTable(game) == nil {
+		http.Error(w, "No encounter data for game: "+game, http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PokemonLocationsResponse{
+		Pokemon:   pokemon.Name,
+		Game:      game,
+		Locations: h.Store.FindPokemonLocations(game, pokemon.Name),
+	})
+}
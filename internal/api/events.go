@@ -0,0 +1,129 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"nuzlocke/internal/runs"
+	"nuzlocke/internal/savefile"
+)
+
+// HandleRunEvents handles GET /api/runs/{id}/events
+// Optional query param: since (RFC 3339 time) to only return newer events
+func (h *Handler) HandleRunEvents(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var since time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			http.Error(w, "Invalid since: "+value, http.StatusBadRequest)
+			return
+		}
+	}
+
+	run, err := h.Runs.Get(id)
+	if err != nil {
+		writeRunError(w, err)
+		return
+	}
+
+	events := []runs.Event{}
+	for _, event := range run.Events {
+		if event.Time.After(since) {
+			events = append(events, event)
+		}
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+// saveEvents turns the changes between two saves into run events
+func (h *Handler) saveEvents(changes []savefile.Change, now time.Time) []runs.Event {
+	events := make([]runs.Event, 0, len(changes))
+	for _, c := range changes {
+		event := runs.Event{
+			Time:        now,
+			Kind:        string(c.Kind),
+			Personality: c.Personality,
+			Species:     h.speciesName(c.SpeciesNum),
+			Nickname:    c.Nickname,
+		}
+		name := event.Nickname
+		if name == "" {
+			name = event.Species
+		}
+
+		switch c.Kind {
+		case savefile.ChangeCaught:
+			event.Text = "Caught " + event.Species
+			if name != event.Species {
+				event.Text += fmt.Sprintf(" (%s)", name)
+			}
+		case savefile.ChangeFainted:
+			event.Text = fmt.Sprintf("%s fainted", name)
+		case savefile.ChangeReleased:
+			event.Text = fmt.Sprintf("%s is no longer in the party or PC", name)
+		case savefile.ChangeDeposited:
+			event.Text = fmt.Sprintf("%s was moved to the PC", name)
+		case savefile.ChangeWithdrawn:
+			event.Text = fmt.Sprintf("%s was moved to the party", name)
+		case savefile.ChangeLeveledUp:
+			event.From, event.To = strconv.Itoa(c.Old), strconv.Itoa(c.New)
+			event.Text = fmt.Sprintf("%s grew from Lv. %d to Lv. %d", name, c.Old, c.New)
+		case savefile.ChangeEvolved:
+			event.From, event.To = h.speciesName(c.Old), h.speciesName(c.New)
+			event.Text = fmt.Sprintf("%s evolved from %s into %s", name, event.From, event.To)
+		case savefile.ChangeLearnedMove:
+			event.To = h.moveName(c.New)
+			event.Text = fmt.Sprintf("%s learned %s", name, event.To)
+		case savefile.ChangeForgotMove:
+			event.From = h.moveName(c.Old)
+			event.Text = fmt.Sprintf("%s forgot %s", name, event.From)
+		case savefile.ChangeHeldItem:
+			event.From, event.To = h.itemName(c.Old), h.itemName(c.New)
+			switch {
+			case c.Old == 0:
+				event.Text = fmt.Sprintf("%s was given %s", name, event.To)
+			case c.New == 0:
+				event.Text = fmt.Sprintf("%s is no longer holding %s", name, event.From)
+			default:
+				event.Text = fmt.Sprintf("%s's held item changed from %s to %s", name, event.From, event.To)
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// speciesName returns a species' name from its number
+func (h *Handler) speciesName(num int) string {
+	if species := h.Store.GetPokemonByNum(num); species != nil {
+		return species.Name
+	}
+	return fmt.Sprintf("Species #%d", num)
+}
+
+// moveName returns a move's name from its number
+func (h *Handler) moveName(num int) string {
+	if move := h.Store.GetMoveByNum(num); move != nil {
+		return move.Name
+	}
+	return fmt.Sprintf("Move #%d", num)
+}
+
+// itemName returns an item's name from its number
+func (h *Handler) itemName(num int) string {
+	if num == 0 {
+		return ""
+	}
+	if item := h.Store.GetItemByNum(num); item != nil {
+		return item.Name
+	}
+	return fmt.Sprintf("Item #%d", num)
+}
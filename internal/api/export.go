@@ -0,0 +1,153 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"nuzlocke/internal/runs"
+	"nuzlocke/internal/savefile"
+)
+
+// routeParty routes /api/party/{slot}/pk3; /api/party/parse is registered on its own
+func (h *Handler) routeParty(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/party"), "/"), "/")
+	if len(parts) != 2 || parts[1] != "pk3" {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	h.HandlePartyPK3(w, r, parts[0])
+}
+
+// routeBoxes routes /api/boxes/{box}/pk3
+func (h *Handler) routeBoxes(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/boxes"), "/"), "/")
+	if len(parts) != 2 || parts[1] != "pk3" {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	h.HandleBoxPK3(w, r, parts[0])
+}
+
+// HandlePartyPK3 handles GET /api/party/{slot}/pk3?run={id}
+// Returns the party Pokemon in slot 1-6 of the run's latest save as a 100-byte .pk3 file
+func (h *Handler) HandlePartyPK3(w http.ResponseWriter, r *http.Request, slot string) {
+	result, ok := h.exportSave(w, r)
+	if !ok {
+		return
+	}
+
+	index, err := strconv.Atoi(slot)
+	if err != nil || index < 1 || index > len(result.Party) {
+		http.Error(w, "No party Pokemon in slot "+slot, http.StatusNotFound)
+		return
+	}
+	p := result.Party[index-1]
+
+	pk3, err := p.PK3()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", pk3FileName(p.SpeciesNum, p.Nickname, p.Personality)))
+	w.Write(pk3)
+}
+
+// HandleBoxPK3 handles GET /api/boxes/{box}/pk3?run={id}
+// Returns the Pokemon in box 1-14 of the run's latest save as a zip of 80-byte .pk3 files,
+// named like PKHeX names its exports so the bundle can be dropped into PKHeX
+func (h *Handler) HandleBoxPK3(w http.ResponseWriter, r *http.Request, box string) {
+	result, ok := h.exportSave(w, r)
+	if !ok {
+		return
+	}
+
+	index, err := strconv.Atoi(box)
+	if err != nil || index < 1 || index > len(result.Boxes) {
+		http.Error(w, "Invalid box: "+box, http.StatusNotFound)
+		return
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, p := range result.Boxes[index-1] {
+		pk3, err := p.PK3()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		file, err := archive.Create(pk3FileName(p.SpeciesNum, p.Nickname, p.Personality))
+		if err != nil {
+			http.Error(w, "Failed to build zip: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		file.Write(pk3)
+	}
+	if err := archive.Close(); err != nil {
+		http.Error(w, "Failed to build zip: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"box-%d.zip\"", index))
+	w.Write(buf.Bytes())
+}
+
+// exportSave parses the latest save of the run given by ?run=
+// Returns false after writing an error response
+func (h *Handler) exportSave(w http.ResponseWriter, r *http.Request) (*savefile.ParseResult, bool) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+	if h.Runs == nil {
+		http.Error(w, "Run tracking is not configured", http.StatusServiceUnavailable)
+		return nil, false
+	}
+	runID := r.URL.Query().Get("run")
+	if runID == "" {
+		http.Error(w, "A run is required", http.StatusBadRequest)
+		return nil, false
+	}
+
+	saveData, err := h.Runs.ReadSave(runID)
+	if errors.Is(err, runs.ErrNoSave) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		writeRunError(w, err)
+		return nil, false
+	}
+	result, err := h.parseSave(saveData, savefile.FormatAuto)
+	if err != nil {
+		http.Error(w, "Failed to parse save file: "+err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	if result.Game.Generation() != 3 {
+		http.Error(w, savefile.ErrNotGen3.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	// ROM hack engines lay out the substructures differently, so PKHeX can't read their records
+	if result.Format != savefile.FormatVanilla {
+		http.Error(w, fmt.Sprintf("Only vanilla saves can be exported as .pk3 (this save is %s)", result.Format), http.StatusBadRequest)
+		return nil, false
+	}
+	return result, true
+}
+
+// pk3FileName names a .pk3 file the way PKHeX does: National Dex number, nickname and PID
+func pk3FileName(speciesNum int, nickname string, personality uint32) string {
+	nickname = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`\/:*?"<>|`, r) {
+			return -1
+		}
+		return r
+	}, nickname)
+	return fmt.Sprintf("%03d - %s - %08X.pk3", speciesNum, nickname, personality)
+}
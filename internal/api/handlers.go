@@ -9,7 +9,9 @@ import (
 
 	"nuzlocke/internal/calc"
 	"nuzlocke/internal/data"
+	"nuzlocke/internal/live"
 	"nuzlocke/internal/models"
+	"nuzlocke/internal/runs"
 	"nuzlocke/internal/savefile"
 )
 
@@ -17,6 +19,8 @@ import (
 type Handler struct {
 	Store      *data.Store
 	Calculator *calc.Calculator
+	Runs       *runs.Store  // Optional: run tracking is disabled when nil
+	Live       *live.Broker // Optional: set when a save file is being watched
 }
 
 // NewHandler creates a new Handler
@@ -34,6 +38,10 @@ type CalculateRequest struct {
 	Defender   *models.BattlePokemon `json:"defender"`
 	Move       *models.BattleMove    `json:"move"`
 	Field      *models.Field         `json:"field"`
+
+	IncomingDamage int                   `json:"incomingDamage,omitempty"` // Counter, Mirror Coat, Metal Burst, Bide
+	AttackerParty  []*models.PartyMember `json:"attackerParty,omitempty"`  // Beat Up
+	AutoField      bool                  `json:"autoField,omitempty"`      // Weather/terrain/Intimidate from abilities
 }
 
 // HandleCalculate handles POST /api/calculate
@@ -70,6 +78,10 @@ func (h *Handler) HandleCalculate(w http.ResponseWriter, r *http.Request) {
 		Move:       req.Move,
 		Field:      req.Field,
 		Generation: req.Generation,
+
+		IncomingDamage: req.IncomingDamage,
+		AttackerParty:  req.AttackerParty,
+		AutoField:      req.AutoField,
 	}
 
 	// Perform calculation
@@ -358,6 +370,9 @@ type MoveDetail struct {
 	Accuracy    int    `json:"accuracy"`
 	PP          int    `json:"pp"`
 	Description string `json:"description"`
+	CurrentPP   *int   `json:"currentPp,omitempty"` // Party Pokemon only
+	MaxPP       int    `json:"maxPp,omitempty"`     // Base PP raised by PP Ups (party Pokemon only)
+	PPUps       int    `json:"ppUps,omitempty"`
 }
 
 // ItemDetail contains item information for tooltips
@@ -374,6 +389,8 @@ type AbilityDetail struct {
 
 // PartyPokemonResponse is the rich response for a party Pokemon
 type PartyPokemonResponse struct {
+	Personality  uint32                 `json:"personality"`
+	Location     string                 `json:"location,omitempty"` // Run encounter location, when linked to a run
 	Species      string                 `json:"species"`
 	Nickname     string                 `json:"nickname"`
 	Level        int                    `json:"level"`
@@ -387,14 +404,23 @@ type PartyPokemonResponse struct {
 	IVs          savefile.PokemonStats  `json:"ivs"`
 	EVs          savefile.PokemonStats  `json:"evs"`
 	CurrentHP    int                    `json:"currentHp"`
+	Status       string                 `json:"status,omitempty"`     // slp, psn, brn, frz, par or tox
+	SleepTurns   int                    `json:"sleepTurns,omitempty"` // Turns left asleep
 	Friendship   int                    `json:"friendship"`
+	Origin       *OriginResponse        `json:"origin"`
+	Gender       string                 `json:"gender"` // "M", "F" or "N" (genderless)
+	Traits       savefile.Traits        `json:"traits"`
 }
 
 // BoxPokemonResponse represents a Pokemon in a PC box with enriched data
 type BoxPokemonResponse struct {
+	Personality  uint32                `json:"personality"`
+	Location     string                `json:"location,omitempty"` // Run encounter location, when linked to a run
 	Species      string                `json:"species"`
 	Nickname     string                `json:"nickname"`
 	Level        int                   `json:"level"`
+	GrowthRate   savefile.GrowthRate   `json:"growthRate"`
+	ExpToNext    uint32                `json:"expToNextLevel"` // 0 at level 100
 	Types        []string              `json:"types"`
 	Nature       string                `json:"nature"`
 	NatureEffect savefile.NatureEffect `json:"natureEffect"`
@@ -405,6 +431,9 @@ type BoxPokemonResponse struct {
 	IVs          savefile.PokemonStats `json:"ivs"`
 	EVs          savefile.PokemonStats `json:"evs"`
 	Friendship   int                   `json:"friendship"`
+	Origin       *OriginResponse       `json:"origin"`
+	Gender       string                `json:"gender"` // "M", "F" or "N" (genderless)
+	Traits       savefile.Traits       `json:"traits"`
 }
 
 // BagItemResponse represents a bag item with resolved name and description
@@ -426,12 +455,25 @@ type BagPocketsResponse struct {
 
 // ParseSaveResponse is the response for the parse save endpoint
 type ParseSaveResponse struct {
-	Party []PartyPokemonResponse   `json:"party"`
-	Boxes [][]BoxPokemonResponse   `json:"boxes"`
-	Bag   *BagPocketsResponse      `json:"bag"`
+	Game      savefile.Game          `json:"game"`   // Game family, e.g. emerald or heartgoldsoulsilver, see savefile.Game
+	Format    savefile.Format        `json:"format"` // vanilla, expansion-1.8, expansion-1.7 or cfru
+	Party     []PartyPokemonResponse `json:"party"`
+	Boxes     [][]BoxPokemonResponse `json:"boxes"`
+	Bag       *BagPocketsResponse    `json:"bag"`
+	Progress  *ProgressResponse      `json:"progress,omitempty"`  // Badges, defeated trainers and story flags
+	Trainer   *savefile.Trainer      `json:"trainer,omitempty"`   // Trainer card, money and coins
+	Pokedex   *PokedexResponse       `json:"pokedex,omitempty"`   // Owned and seen species
+	Integrity *savefile.Integrity    `json:"integrity,omitempty"` // Save slot checksums and which slot was parsed
+	Run       *runs.LinkResult       `json:"run,omitempty"`       // Set when ?run={id} is given
+	Events    []runs.Event           `json:"events,omitempty"`    // Changes since the run's previous save
 }
 
 // HandleParseSave handles POST /api/nuzlocke/parse
+// With ?run={id}, the save's Pokemon are linked to that run's encounters and the changes
+// since the run's previous save are added to its event log
+// With ?format=vanilla, expansion (the latest), expansion-1.8, expansion-1.7 or cfru, the save
+// is decoded as that format instead of detecting it; Gen 1, 2 and 4 saves are detected by
+// their layout and ignore the format
 func (h *Handler) HandleParseSave(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -445,17 +487,59 @@ func (h *Handler) HandleParseSave(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	format, err := savefile.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Parse the save file
-	result, err := savefile.ParseGen3Save(saveData)
+	result, err := h.parseSave(saveData, format)
 	if err != nil {
 		http.Error(w, "Failed to parse save file: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	// Link to a run's encounters
+	var link *runs.LinkResult
+	var events []runs.Event
+	if runID := r.URL.Query().Get("run"); runID != "" {
+		if h.Runs == nil {
+			http.Error(w, "Run tracking is not configured", http.StatusServiceUnavailable)
+			return
+		}
+		link, events, err = h.linkSaveToRun(runID, saveData, result)
+		if err != nil {
+			writeRunError(w, err)
+			return
+		}
+	}
+	response := h.buildParseSaveResponse(result, link, events)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// buildParseSaveResponse resolves a parsed save's species, moves, abilities and items
+func (h *Handler) buildParseSaveResponse(result *savefile.ParseResult, link *runs.LinkResult, events []runs.Event) *ParseSaveResponse {
+	location := func(personality uint32) string {
+		if link == nil {
+			return ""
+		}
+		return link.Linked[personality]
+	}
+
 	// Build rich response
-	response := ParseSaveResponse{
-		Party: make([]PartyPokemonResponse, 0, len(result.Party)),
-		Boxes: make([][]BoxPokemonResponse, len(result.Boxes)),
+	response := &ParseSaveResponse{
+		Game:      result.Game,
+		Format:    result.Format,
+		Party:     make([]PartyPokemonResponse, 0, len(result.Party)),
+		Boxes:     make([][]BoxPokemonResponse, len(result.Boxes)),
+		Progress:  h.buildProgressResponse(result),
+		Trainer:   result.Trainer,
+		Pokedex:   h.buildPokedexResponse(result.Pokedex),
+		Integrity: result.Integrity,
+		Run:       link,
+		Events:    events,
 	}
 	for i := range response.Boxes {
 		response.Boxes[i] = []BoxPokemonResponse{}
@@ -463,6 +547,8 @@ func (h *Handler) HandleParseSave(w http.ResponseWriter, r *http.Request) {
 
 	for _, p := range result.Party {
 		pokemon := PartyPokemonResponse{
+			Personality:  p.Personality,
+			Location:     location(p.Personality),
 			Nickname:     p.Nickname,
 			Level:        p.Level,
 			Nature:       p.Nature,
@@ -471,7 +557,12 @@ func (h *Handler) HandleParseSave(w http.ResponseWriter, r *http.Request) {
 			IVs:          p.IVs,
 			EVs:          p.EVs,
 			CurrentHP:    p.CurrentHP,
+			Status:       p.Status.Condition,
+			SleepTurns:   p.Status.SleepTurns,
 			Friendship:   p.Friendship,
+			Origin:       h.buildOriginResponse(p.Origin, p.Pokerus, result.Game),
+			Gender:       "N",
+			Traits:       p.Traits,
 		}
 
 		// Resolve species
@@ -479,6 +570,7 @@ func (h *Handler) HandleParseSave(w http.ResponseWriter, r *http.Request) {
 		if speciesData != nil {
 			pokemon.Species = speciesData.Name
 			pokemon.Types = speciesData.Types
+			pokemon.Gender = pokemonGender(speciesData, p.Personality)
 
 			// Resolve ability based on slot (0, 1, or 2 for hidden)
 			// If requested slot doesn't exist, fall back to slot 0
@@ -519,7 +611,7 @@ func (h *Handler) HandleParseSave(w http.ResponseWriter, r *http.Request) {
 
 		// Resolve moves with details
 		pokemon.Moves = make([]MoveDetail, 0, len(p.MoveNums))
-		for _, moveNum := range p.MoveNums {
+		for i, moveNum := range p.MoveNums {
 			move := h.Store.GetMoveByNum(moveNum)
 			if move != nil {
 				accuracy := 0
@@ -533,7 +625,7 @@ func (h *Handler) HandleParseSave(w http.ResponseWriter, r *http.Request) {
 						accuracy = 100 // "true" means never-miss
 					}
 				}
-				pokemon.Moves = append(pokemon.Moves, MoveDetail{
+				detail := MoveDetail{
 					Name:        move.Name,
 					Type:        move.Type,
 					Category:    move.Category,
@@ -541,7 +633,13 @@ func (h *Handler) HandleParseSave(w http.ResponseWriter, r *http.Request) {
 					Accuracy:    accuracy,
 					PP:          move.PP,
 					Description: move.ShortDesc,
-				})
+				}
+				if i < len(p.PP) && i < len(p.PPUps) {
+					detail.CurrentPP = &p.PP[i]
+					detail.MaxPP = savefile.MaxPP(move.PP, p.PPUps[i])
+					detail.PPUps = p.PPUps[i]
+				}
+				pokemon.Moves = append(pokemon.Moves, detail)
 			}
 		}
 
@@ -552,13 +650,20 @@ func (h *Handler) HandleParseSave(w http.ResponseWriter, r *http.Request) {
 	for boxIdx, box := range result.Boxes {
 		for _, p := range box {
 			pokemon := BoxPokemonResponse{
+				Personality:  p.Personality,
+				Location:     location(p.Personality),
 				Nickname:     p.Nickname,
 				Level:        p.Level,
+				GrowthRate:   h.growthRate(p.SpeciesNum),
+				ExpToNext:    h.growthRate(p.SpeciesNum).ExpToNextLevel(p.Experience),
 				Nature:       p.Nature,
 				NatureEffect: savefile.GetNatureEffect(p.Nature),
 				IVs:          p.IVs,
 				EVs:          p.EVs,
 				Friendship:   p.Friendship,
+				Origin:       h.buildOriginResponse(p.Origin, p.Pokerus, result.Game),
+				Gender:       "N",
+				Traits:       p.Traits,
 			}
 
 			// Resolve species
@@ -566,6 +671,7 @@ func (h *Handler) HandleParseSave(w http.ResponseWriter, r *http.Request) {
 			if speciesData != nil {
 				pokemon.Species = speciesData.Name
 				pokemon.Types = speciesData.Types
+				pokemon.Gender = pokemonGender(speciesData, p.Personality)
 
 				// Calculate stats from base stats, IVs, EVs, level, nature
 				natureData := h.Store.GetNature(strings.ToLower(p.Nature))
@@ -685,6 +791,5 @@ func (h *Handler) HandleParseSave(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	return response
 }
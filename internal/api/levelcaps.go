@@ -0,0 +1,139 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"nuzlocke/internal/runs"
+)
+
+// defaultLevelCapPreset is used when neither ?preset= nor the run's ruleset gives level caps
+const defaultLevelCapPreset = "emerald"
+
+// LevelCapMember is a party member checked against the level cap
+type LevelCapMember struct {
+	Species        string `json:"species"`
+	Nickname       string `json:"nickname"`
+	Level          int    `json:"level"`
+	Personality    uint32 `json:"personality"`
+	Experience     uint32 `json:"experience"`
+	OverCap        bool   `json:"overCap"`
+	LevelsOver     int    `json:"levelsOver,omitempty"`
+	ExperienceOver uint32 `json:"experienceOver,omitempty"` // Experience gained past reaching the cap level
+}
+
+// LevelCapCheckResponse is the response for POST /api/levelcaps/check
+type LevelCapCheckResponse struct {
+	Preset   string           `json:"preset,omitempty"`
+	Progress runs.Progress    `json:"progress"`
+	Cap      *runs.LevelCap   `json:"cap"` // Nil once every boss is beaten
+	Party    []LevelCapMember `json:"party"`
+	OverCap  int              `json:"overCap"` // Number of party members over the cap
+}
+
+// routeLevelCaps routes level cap requests based on path
+func (h *Handler) routeLevelCaps(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/levelcaps"), "/")
+	switch path {
+	case "":
+		h.HandleListLevelCapPresets(w, r)
+	case "check":
+		h.HandleCheckLevelCap(w, r)
+	default:
+		http.Error(w, "Not found", http.StatusNotFound)
+	}
+}
+
+// HandleListLevelCapPresets handles GET /api/levelcaps
+func (h *Handler) HandleListLevelCapPresets(w http.ResponseWriter, r *http.Request) {
+	presets := []*runs.LevelCapRules{}
+	for _, name := range runs.LevelCapPresets() {
+		rules, _ := runs.LevelCapPreset(name)
+		presets = append(presets, rules)
+	}
+	writeJSON(w, http.StatusOK, presets)
+}
+
+// HandleCheckLevelCap handles POST /api/levelcaps/check
+// Query params: preset, run, badges, defeated (comma-separated trainer IDs)
+// The body is a save file; it can be left empty when a run is given to use the run's latest save.
+// Caps come from ?preset=, then the run's ruleset, then the Emerald preset.
+// Badges and defeated trainers not given in the query are read from the save's event flags.
+func (h *Handler) HandleCheckLevelCap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	progress := runs.Progress{}
+	if badges := query.Get("badges"); badges != "" {
+		n, err := strconv.Atoi(badges)
+		if err != nil || n < 0 || n > 8 {
+			http.Error(w, "Invalid badges: "+badges, http.StatusBadRequest)
+			return
+		}
+		progress.Badges = n
+	}
+	if defeated := query.Get("defeated"); defeated != "" {
+		for _, id := range strings.Split(defeated, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				progress.Defeated = append(progress.Defeated, id)
+			}
+		}
+	}
+
+	result, run, ok := h.saveFromRequest(w, r)
+	if !ok {
+		return
+	}
+	saved := h.saveProgress(result)
+	if query.Get("badges") == "" {
+		progress.Badges = saved.Badges
+	}
+	if len(progress.Defeated) == 0 {
+		progress.Defeated = saved.Defeated
+	}
+
+	var rules *runs.LevelCapRules
+	if preset := query.Get("preset"); preset != "" {
+		if rules, ok = runs.LevelCapPreset(preset); !ok {
+			http.Error(w, "Unknown level cap preset: "+preset, http.StatusBadRequest)
+			return
+		}
+	} else if run != nil && run.Ruleset.LevelCaps != nil {
+		rules = run.Ruleset.LevelCaps
+	} else {
+		rules, _ = runs.LevelCapPreset(defaultLevelCapPreset)
+	}
+
+	response := LevelCapCheckResponse{
+		Preset:   rules.Preset,
+		Progress: progress,
+		Cap:      rules.CurrentCap(progress),
+		Party:    make([]LevelCapMember, 0, len(result.Party)),
+	}
+	for _, p := range result.Party {
+		member := LevelCapMember{
+			Nickname:    p.Nickname,
+			Level:       p.Level,
+			Personality: p.Personality,
+			Experience:  p.Experience,
+		}
+		if species := h.Store.GetPokemonByNum(p.SpeciesNum); species != nil {
+			member.Species = species.Name
+		}
+		if response.Cap != nil && p.Level > response.Cap.Level {
+			member.OverCap = true
+			member.LevelsOver = p.Level - response.Cap.Level
+			if capExp := h.growthRate(p.SpeciesNum).ExpForLevel(response.Cap.Level); p.Experience > capExp {
+				member.ExperienceOver = p.Experience - capExp
+			}
+			response.OverCap++
+		}
+		response.Party = append(response.Party, member)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"nuzlocke/internal/live"
+	"nuzlocke/internal/savefile"
+)
+
+// liveHeartbeat is how often a comment is sent to keep idle connections open
+const liveHeartbeat = 15 * time.Second
+
+// LiveSaveEvent is the payload of a "save" event: the watched file's parsed contents
+type LiveSaveEvent struct {
+	File     string    `json:"file"`
+	Checksum string    `json:"checksum"`
+	ModTime  time.Time `json:"modTime"`
+	*ParseSaveResponse
+}
+
+// LiveErrorEvent is the payload of a "parse-error" event, sent when the watched file can't be parsed
+type LiveErrorEvent struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+// PublishSave parses a save read by the watcher and pushes it to live clients
+func (h *Handler) PublishSave(snapshot live.Snapshot) {
+	file := filepath.Base(snapshot.Path)
+
+	result, err := h.parseSave(snapshot.Data, savefile.FormatAuto)
+	if err != nil {
+		log.Printf("Watcher: failed to parse %s: %v", snapshot.Path, err)
+		h.publishLive("parse-error", snapshot.Checksum, LiveErrorEvent{File: file, Error: err.Error()})
+		return
+	}
+
+	log.Printf("Watcher: %s changed, %d party Pokemon", snapshot.Path, len(result.Party))
+	h.publishLive("save", snapshot.Checksum, LiveSaveEvent{
+		File:              file,
+		Checksum:          snapshot.Checksum,
+		ModTime:           snapshot.ModTime,
+		ParseSaveResponse: h.buildParseSaveResponse(result, nil, nil),
+	})
+}
+
+// publishLive encodes a payload and sends it to live clients
+func (h *Handler) publishLive(name, id string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Watcher: failed to encode %s event: %v", name, err)
+		return
+	}
+	h.Live.Publish(live.Event{Name: name, ID: id, Data: data})
+}
+
+// HandleLiveEvents handles GET /api/live/events, a Server-Sent Events stream of the watched
+// save file. The current save is sent on connect, then a "save" event each time it changes.
+func (h *Handler) HandleLiveEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.Live == nil {
+		http.Error(w, "Live updates are not enabled (start the server with -watch)", http.StatusServiceUnavailable)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	events, unsubscribe := h.Live.Subscribe()
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(liveHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		case event := <-events:
+			fmt.Fprintf(w, "event: %s\nid: %s\ndata: %s\n\n", event.Name, event.ID, event.Data)
+		}
+		flusher.Flush()
+	}
+}
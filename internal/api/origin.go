@@ -0,0 +1,56 @@
+package api
+
+import (
+	"nuzlocke/internal/data"
+	"nuzlocke/internal/savefile"
+)
+
+// OriginResponse is where and how a save Pokemon was obtained, with names resolved
+type OriginResponse struct {
+	MetLocation   string `json:"metLocation"`
+	MetLocationID int    `json:"metLocationId"` // Map section ID in Gen 3, location ID in Gen 4
+	MetLevel      int    `json:"metLevel"`
+	Hatched       bool   `json:"hatched"` // Met level 0 means it hatched from an egg
+	Ball          string `json:"ball"`
+	Game          string `json:"game"`
+	OTName        string `json:"otName"`
+	OTGender      string `json:"otGender"`
+	Pokerus       string `json:"pokerus"` // "none", "infected" or "cured"
+}
+
+// buildOriginResponse resolves a save Pokemon's origin data
+// Met locations are only named for Gen 3 saves; other location IDs are reported as-is.
+// Gen 1 and Gen 2 Pokemon record no origin game and, outside Crystal, no met data.
+func (h *Handler) buildOriginResponse(origin savefile.Origin, pokerus savefile.Pokerus, game savefile.Game) *OriginResponse {
+	response := &OriginResponse{
+		MetLocationID: origin.MetLocation,
+		MetLevel:      origin.MetLevel,
+		OTName:        origin.OTName,
+		OTGender:      origin.OTGender,
+		Pokerus:       pokerus.Status(),
+	}
+	if game.Generation() >= 3 {
+		response.Hatched = origin.MetLevel == 0
+		response.Game = savefile.GameName(origin.Game)
+	}
+	if game.Generation() == 3 {
+		response.MetLocation = savefile.LocationName(origin.MetLocation)
+	}
+	if ball := h.Store.GetItemByNum(origin.BallItemNum); ball != nil {
+		response.Ball = ball.Name
+	}
+	return response
+}
+
+// pokemonGender returns a save Pokemon's gender from its species and personality value
+// Species without a gender ratio are 50% female
+func pokemonGender(species *data.Pokemon, personality uint32) string {
+	if species.Gender != "" {
+		return species.Gender
+	}
+	femaleRatio := 0.5
+	if species.GenderRatio != nil {
+		femaleRatio = species.GenderRatio.F
+	}
+	return savefile.GenderFromPersonality(personality, femaleRatio)
+}
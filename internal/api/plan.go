@@ -0,0 +1,53 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"nuzlocke/internal/planner"
+	"nuzlocke/internal/runs"
+)
+
+// HandlePlanBoss handles POST /api/plan/boss
+// Query params: trainer (required), run (optional)
+// The body is a save file; it can be left empty when a run is given to use the run's latest save.
+// With a run, Pokemon in the run's graveyard are left out. The save must be from the trainer's game.
+func (h *Handler) HandlePlanBoss(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	trainerID := r.URL.Query().Get("trainer")
+	if trainerID == "" {
+		http.Error(w, "Trainer is required", http.StatusBadRequest)
+		return
+	}
+	trainer := h.Store.GetTrainer(trainerID)
+	if trainer == nil {
+		http.Error(w, "Trainer not found", http.StatusNotFound)
+		return
+	}
+
+	result, run, ok := h.saveFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	// The trainer's party and the battle mechanics come from its game, so the save has to match
+	if string(result.Game) != trainer.Game {
+		http.Error(w, fmt.Sprintf("The save is from %s but %s is a %s trainer", result.Game, trainer.Name, trainer.Game), http.StatusBadRequest)
+		return
+	}
+
+	var dead func(personality uint32) bool
+	if run != nil {
+		dead = func(personality uint32) bool {
+			enc := run.FindByPersonality(personality)
+			return enc != nil && enc.Membership == runs.MemberGraveyard
+		}
+	}
+
+	team := planner.SaveTeam(h.Store, result, dead)
+	writeJSON(w, http.StatusOK, planner.New(h.Store, h.Calculator).PlanBoss(trainer, team, result.Game.Generation()))
+}
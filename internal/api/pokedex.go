@@ -0,0 +1,39 @@
+package api
+
+import "nuzlocke/internal/savefile"
+
+// PokedexEntry is a Pokedex number resolved to a species
+type PokedexEntry struct {
+	Num     int    `json:"num"`
+	Species string `json:"species"`
+}
+
+// PokedexResponse is a save's Pokedex with species names
+type PokedexResponse struct {
+	OwnedCount int            `json:"ownedCount"`
+	SeenCount  int            `json:"seenCount"`
+	Owned      []PokedexEntry `json:"owned"`
+	Seen       []PokedexEntry `json:"seen"`
+}
+
+// buildPokedexResponse resolves a save's Pokedex, returning nil if the save has none
+func (h *Handler) buildPokedexResponse(pokedex *savefile.Pokedex) *PokedexResponse {
+	if pokedex == nil {
+		return nil
+	}
+	return &PokedexResponse{
+		OwnedCount: len(pokedex.Owned),
+		SeenCount:  len(pokedex.Seen),
+		Owned:      h.pokedexEntries(pokedex.Owned),
+		Seen:       h.pokedexEntries(pokedex.Seen),
+	}
+}
+
+// pokedexEntries resolves National Dex numbers to species names
+func (h *Handler) pokedexEntries(nums []int) []PokedexEntry {
+	entries := make([]PokedexEntry, 0, len(nums))
+	for _, num := range nums {
+		entries = append(entries, PokedexEntry{Num: num, Species: h.speciesName(num)})
+	}
+	return entries
+}
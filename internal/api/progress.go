@@ -0,0 +1,35 @@
+package api
+
+import (
+	"nuzlocke/internal/runs"
+	"nuzlocke/internal/savefile"
+)
+
+// ProgressResponse is a save's progress with its defeated trainer flags matched to trainer data
+type ProgressResponse struct {
+	*savefile.Progress
+	Defeated []string `json:"defeated"` // IDs of the trainers in /api/trainers that have been beaten
+}
+
+// buildProgressResponse resolves a save's progress, returning nil if the save has none
+// Defeated trainer flags are matched against the trainer data of the detected game
+func (h *Handler) buildProgressResponse(result *savefile.ParseResult) *ProgressResponse {
+	if result.Progress == nil {
+		return nil
+	}
+	response := &ProgressResponse{Progress: result.Progress, Defeated: []string{}}
+	for _, trainer := range h.Store.DefeatedTrainers(string(result.Game), result.Progress.DefeatedTrainers) {
+		response.Defeated = append(response.Defeated, trainer.ID)
+	}
+	return response
+}
+
+// saveProgress returns the badges and defeated trainers recorded in a save
+func (h *Handler) saveProgress(result *savefile.ParseResult) runs.Progress {
+	progress := runs.Progress{}
+	if resolved := h.buildProgressResponse(result); resolved != nil {
+		progress.Badges = resolved.BadgeCount
+		progress.Defeated = resolved.Defeated
+	}
+	return progress
+}
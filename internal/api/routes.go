@@ -20,6 +20,18 @@ func (h *Handler) SetupRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/search/pokemon", h.HandleSearchPokemon)
 	mux.HandleFunc("/api/search/moves", h.HandleSearchMoves)
 	mux.HandleFunc("/api/party/parse", h.HandleParseSave)
+	mux.HandleFunc("/api/party/", h.routeParty)
+	mux.HandleFunc("/api/boxes/", h.routeBoxes)
+	mux.HandleFunc("/api/plan/boss", h.HandlePlanBoss)
+	mux.HandleFunc("/api/levelcaps/", h.routeLevelCaps)
+	mux.HandleFunc("/api/levelcaps", h.routeLevelCaps)
+	mux.HandleFunc("/api/encounters/", h.routeEncounters)
+	mux.HandleFunc("/api/encounters", h.routeEncounters)
+	mux.HandleFunc("/api/trainers/", h.routeTrainers)
+	mux.HandleFunc("/api/trainers", h.routeTrainers)
+	mux.HandleFunc("/api/runs/", h.routeRuns)
+	mux.HandleFunc("/api/runs", h.routeRuns)
+	mux.HandleFunc("/api/live/events", h.HandleLiveEvents)
 }
 
 // routePokemon routes Pokemon requests based on path
@@ -30,7 +42,7 @@ func (h *Handler) routePokemon(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check for sub-routes: /api/pokemon/{id}/learnset or /api/pokemon/{id}/full
+	// Check for sub-routes: /api/pokemon/{id}/learnset, /api/pokemon/{id}/full or /api/pokemon/{id}/locations
 	if strings.HasSuffix(path, "/learnset") {
 		h.HandleGetLearnset(w, r)
 		return
@@ -39,6 +51,10 @@ func (h *Handler) routePokemon(w http.ResponseWriter, r *http.Request) {
 		h.HandleGetPokemonFull(w, r)
 		return
 	}
+	if strings.HasSuffix(path, "/locations") {
+		h.HandleGetPokemonLocations(w, r)
+		return
+	}
 
 	h.HandleGetPokemon(w, r)
 }
@@ -0,0 +1,390 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"nuzlocke/internal/runs"
+	"nuzlocke/internal/savefile"
+)
+
+// CreateRunRequest is the JSON body for creating or updating a run
+type CreateRunRequest struct {
+	Name    string        `json:"name"`
+	Game    string        `json:"game"`
+	Ruleset *runs.Ruleset `json:"ruleset,omitempty"`
+}
+
+// RunMembersResponse groups a run's obtained Pokemon by where they are
+type RunMembersResponse struct {
+	Party     []*runs.Encounter `json:"party"`
+	Box       []*runs.Encounter `json:"box"`
+	Graveyard []*runs.Encounter `json:"graveyard"`
+}
+
+// routeRuns routes run requests based on path
+// /api/runs, /api/runs/{id}, /api/runs/{id}/encounters[/{location}], /api/runs/{id}/members,
+// /api/runs/{id}/eligibility/{location}, /api/runs/{id}/events
+func (h *Handler) routeRuns(w http.ResponseWriter, r *http.Request) {
+	if h.Runs == nil {
+		http.Error(w, "Run tracking is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/runs"), "/")
+	if path == "" {
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleListRuns(w, r)
+		case http.MethodPost:
+			h.HandleCreateRun(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	parts := strings.SplitN(path, "/", 3)
+	id := parts[0]
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetRun(w, r, id)
+		case http.MethodPut:
+			h.HandleUpdateRun(w, r, id)
+		case http.MethodDelete:
+			h.HandleDeleteRun(w, r, id)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	switch parts[1] {
+	case "encounters":
+		if len(parts) == 2 {
+			h.HandleListEncounters(w, r, id)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			h.HandleSetEncounter(w, r, id, parts[2])
+		case http.MethodDelete:
+			h.HandleDeleteEncounter(w, r, id, parts[2])
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	case "members":
+		h.HandleRunMembers(w, r, id)
+	case "eligibility":
+		if len(parts) < 3 {
+			http.Error(w, "Location is required", http.StatusBadRequest)
+			return
+		}
+		h.HandleRunEligibility(w, r, id, parts[2])
+	case "events":
+		h.HandleRunEvents(w, r, id)
+	default:
+		http.Error(w, "Not found", http.StatusNotFound)
+	}
+}
+
+// HandleListRuns handles GET /api/runs
+func (h *Handler) HandleListRuns(w http.ResponseWriter, r *http.Request) {
+	list, err := h.Runs.List()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// HandleCreateRun handles POST /api/runs
+func (h *Handler) HandleCreateRun(w http.ResponseWriter, r *http.Request) {
+	var req CreateRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	run := runs.NewRun(req.Name, req.Game)
+	if req.Ruleset != nil {
+		run.Ruleset = *req.Ruleset
+	}
+	if err := h.Runs.Create(run); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusCreated, run)
+}
+
+// HandleGetRun handles GET /api/runs/{id}
+func (h *Handler) HandleGetRun(w http.ResponseWriter, r *http.Request, id string) {
+	run, err := h.Runs.Get(id)
+	if err != nil {
+		writeRunError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, run)
+}
+
+// HandleUpdateRun handles PUT /api/runs/{id} (name, game and ruleset)
+func (h *Handler) HandleUpdateRun(w http.ResponseWriter, r *http.Request, id string) {
+	var req CreateRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	run, err := h.Runs.Update(id, func(run *runs.Run) error {
+		if req.Name != "" {
+			run.Name = req.Name
+		}
+		if req.Game != "" {
+			run.Game = req.Game
+		}
+		if req.Ruleset != nil {
+			run.Ruleset = *req.Ruleset
+		}
+		return nil
+	})
+	if err != nil {
+		writeRunError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, run)
+}
+
+// HandleDeleteRun handles DELETE /api/runs/{id}
+func (h *Handler) HandleDeleteRun(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.Runs.Delete(id); err != nil {
+		writeRunError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleListEncounters handles GET /api/runs/{id}/encounters
+func (h *Handler) HandleListEncounters(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	run, err := h.Runs.Get(id)
+	if err != nil {
+		writeRunError(w, err)
+		return
+	}
+
+	encounters := make([]*runs.Encounter, 0, len(run.Encounters))
+	for _, enc := range run.Encounters {
+		encounters = append(encounters, enc)
+	}
+	sort.Slice(encounters, func(i, j int) bool {
+		return encounters[i].Location < encounters[j].Location
+	})
+	writeJSON(w, http.StatusOK, encounters)
+}
+
+// HandleSetEncounter handles PUT /api/runs/{id}/encounters/{location}
+func (h *Handler) HandleSetEncounter(w http.ResponseWriter, r *http.Request, id, location string) {
+	var enc runs.Encounter
+	if err := json.NewDecoder(r.Body).Decode(&enc); err != nil {
+		http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	enc.Location = location
+	if species := h.Store.GetPokemon(enc.Species); species != nil {
+		enc.Species = species.Name
+	}
+
+	run, err := h.Runs.Update(id, func(run *runs.Run) error {
+		return run.SetEncounter(&enc)
+	})
+	if err != nil {
+		writeRunError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, run.Encounters[location])
+}
+
+// HandleDeleteEncounter handles DELETE /api/runs/{id}/encounters/{location}
+func (h *Handler) HandleDeleteEncounter(w http.ResponseWriter, r *http.Request, id, location string) {
+	_, err := h.Runs.Update(id, func(run *runs.Run) error {
+		delete(run.Encounters, location)
+		return nil
+	})
+	if err != nil {
+		writeRunError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleRunMembers handles GET /api/runs/{id}/members
+func (h *Handler) HandleRunMembers(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	run, err := h.Runs.Get(id)
+	if err != nil {
+		writeRunError(w, err)
+		return
+	}
+
+	response := RunMembersResponse{
+		Party:     run.Members(runs.MemberParty),
+		Box:       run.Members(runs.MemberBox),
+		Graveyard: run.Members(runs.MemberGraveyard),
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// HandleRunEligibility handles GET /api/runs/{id}/eligibility/{location}
+// Optional query params: game (defaults to the run's game), repel (lead Pokemon's level)
+func (h *Handler) HandleRunEligibility(w http.ResponseWriter, r *http.Request, id, location string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	run, err := h.Runs.Get(id)
+	if err != nil {
+		writeRunError(w, err)
+		return
+	}
+
+	game := run.Game
+	if r.URL.Query().Get("game") != "" || h.Store.GetEncounterTable(game) == nil {
+		game = encounterGame(r)
+	}
+
+	repelLevel := 0
+	if repel := r.URL.Query().Get("repel"); repel != "" {
+		repelLevel, err = strconv.Atoi(repel)
+		if err != nil || repelLevel < 0 || repelLevel > 100 {
+			http.Error(w, "Invalid repel level", http.StatusBadRequest)
+			return
+		}
+	}
+
+	eligibility, err := run.LocationEligibility(h.Store, game, location, repelLevel)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, eligibility)
+}
+
+// linkSaveToRun links a parsed save's Pokemon to a run's encounters by personality value,
+// logs the changes since the run's previous save and keeps the save file with the run
+func (h *Handler) linkSaveToRun(runID string, save []byte, result *savefile.ParseResult) (*runs.LinkResult, []runs.Event, error) {
+	var pokemon []runs.SavePokemon
+	for _, p := range result.Party {
+		pokemon = append(pokemon, h.savePokemon(p.Personality, p.SpeciesNum, p.Nickname, true))
+	}
+	for _, box := range result.Boxes {
+		for _, p := range box {
+			pokemon = append(pokemon, h.savePokemon(p.Personality, p.SpeciesNum, p.Nickname, false))
+		}
+	}
+
+	var link *runs.LinkResult
+	var events []runs.Event
+	run, err := h.Runs.LinkSave(runID, save, func(run *runs.Run, previous []byte) error {
+		// The first save linked to a run has nothing to diff against
+		if previous != nil {
+			if older, err := h.parseSave(previous, result.Format); err == nil {
+				events = h.saveEvents(savefile.Diff(older, result), time.Now().UTC())
+			}
+		}
+		link = run.LinkSave(pokemon, h.Store.SameEvolutionFamily)
+		run.AddEvents(events)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return link, run.Events[len(run.Events)-len(events):], nil
+}
+
+// saveFromRequest parses the save file in the request body, falling back to the latest save
+// of the run given by ?run= when the body is empty
+// Returns false after writing an error response
+func (h *Handler) saveFromRequest(w http.ResponseWriter, r *http.Request) (*savefile.ParseResult, *runs.Run, bool) {
+	saveData, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	var run *runs.Run
+	if runID := r.URL.Query().Get("run"); runID != "" {
+		if h.Runs == nil {
+			http.Error(w, "Run tracking is not configured", http.StatusServiceUnavailable)
+			return nil, nil, false
+		}
+		run, err = h.Runs.Get(runID)
+		if err != nil {
+			writeRunError(w, err)
+			return nil, nil, false
+		}
+		if len(saveData) == 0 {
+			saveData, err = h.Runs.ReadSave(runID)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return nil, nil, false
+			}
+		}
+	}
+	if len(saveData) == 0 {
+		http.Error(w, "A save file or run is required", http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	result, err := h.parseSave(saveData, savefile.FormatAuto)
+	if err != nil {
+		http.Error(w, "Failed to parse save file: "+err.Error(), http.StatusBadRequest)
+		return nil, nil, false
+	}
+	return result, run, true
+}
+
+// savePokemon builds a runs.SavePokemon, resolving the species name
+func (h *Handler) savePokemon(personality uint32, speciesNum int, nickname string, inParty bool) runs.SavePokemon {
+	p := runs.SavePokemon{
+		Personality: personality,
+		Nickname:    nickname,
+		InParty:     inParty,
+	}
+	if species := h.Store.GetPokemonByNum(speciesNum); species != nil {
+		p.Species = species.Name
+	}
+	return p
+}
+
+// writeRunError writes a run store error with the matching status code
+func writeRunError(w http.ResponseWriter, err error) {
+	if errors.Is(err, runs.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
+
+// writeJSON writes v as JSON with the given status code
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
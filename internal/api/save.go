@@ -0,0 +1,22 @@
+package api
+
+import "nuzlocke/internal/savefile"
+
+// parseSave parses a save file and corrects box levels with each species' growth rate
+// FormatAuto detects whether the save is from the vanilla games or a ROM hack engine
+func (h *Handler) parseSave(save []byte, format savefile.Format) (*savefile.ParseResult, error) {
+	result, err := savefile.ParseSave(save, format)
+	if err != nil {
+		return nil, err
+	}
+	result.ApplyGrowthRates(h.growthRate)
+	return result, nil
+}
+
+// growthRate returns a species' experience curve, defaulting to Medium Fast
+func (h *Handler) growthRate(speciesNum int) savefile.GrowthRate {
+	if species := h.Store.GetPokemonByNum(speciesNum); species != nil && species.GrowthRate != "" {
+		return savefile.GrowthRate(species.GrowthRate)
+	}
+	return savefile.GrowthMediumFast
+}
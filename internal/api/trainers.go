@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"nuzlocke/internal/data"
+)
+
+// routeTrainers routes trainer requests based on path
+func (h *Handler) routeTrainers(w http.ResponseWriter, r *http.Request) {
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/trainers"), "/")
+	if id == "" {
+		h.HandleListTrainers(w, r)
+		return
+	}
+	h.HandleGetTrainer(w, r, id)
+}
+
+// HandleListTrainers handles GET /api/trainers
+// Query params: game, class, badges (exact), minBadges, maxBadges
+func (h *Handler) HandleListTrainers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := data.TrainerFilter{
+		Game:      query.Get("game"),
+		Class:     query.Get("class"),
+		MinBadges: -1,
+		MaxBadges: -1,
+	}
+
+	// badges=N is shorthand for minBadges=N&maxBadges=N
+	for _, param := range []string{"badges", "minBadges", "maxBadges"} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			http.Error(w, "Invalid "+param+": "+value, http.StatusBadRequest)
+			return
+		}
+		switch param {
+		case "badges":
+			filter.MinBadges, filter.MaxBadges = n, n
+		case "minBadges":
+			filter.MinBadges = n
+		case "maxBadges":
+			filter.MaxBadges = n
+		}
+	}
+
+	writeJSON(w, http.StatusOK, h.Store.ListTrainers(filter))
+}
+
+// HandleGetTrainer handles GET /api/trainers/{id}
+func (h *Handler) HandleGetTrainer(w http.ResponseWriter, r *http.Request, id string) {
+	trainer := h.Store.GetTrainer(id)
+	if trainer == nil {
+		http.Error(w, "Trainer not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, trainer)
+}
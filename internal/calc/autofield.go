@@ -0,0 +1,276 @@
+package calc
+
+import (
+	"fmt"
+
+	"nuzlocke/internal/data"
+	"nuzlocke/internal/models"
+)
+
+// fieldAbilityGeneration is the generation a field-setting ability was introduced
+// Drizzle, Drought and Sand Stream exist from Gen 3
+var fieldAbilityGeneration = map[string]int{
+	"snowwarning":     4,
+	"primordialsea":   6,
+	"desolateland":    6,
+	"deltastream":     6,
+	"electricsurge":   7,
+	"grassysurge":     7,
+	"mistysurge":      7,
+	"psychicsurge":    7,
+	"orichalcumpulse": 9,
+	"hadronengine":    9,
+}
+
+// strongWeathers can only be replaced by another strong weather
+var strongWeathers = map[string]bool{
+	"heavyrain":   true,
+	"harshsun":    true,
+	"strongwinds": true,
+}
+
+// intimidateImmuneAbilities block Intimidate's Attack drop, keyed to the generation they started doing so
+var intimidateImmuneAbilities = map[string]int{
+	"hypercutter":   3,
+	"clearbody":     3,
+	"whitesmoke":    3,
+	"fullmetalbody": 7,
+	"innerfocus":    8,
+	"oblivious":     8,
+	"owntempo":      8,
+	"scrappy":       8,
+}
+
+// weatherSpeedAbilities double speed in the given weather
+var weatherSpeedAbilities = map[string][]string{
+	"swiftswim":   {"rain", "heavyrain"},
+	"chlorophyll": {"sun", "harshsun"},
+	"sandrush":    {"sand"},
+	"slushrush":   {"snow", "hail"},
+}
+
+// EffectiveSpeed returns a Pokemon's in-battle speed including boosts, Choice Scarf,
+// weather abilities, Tailwind and paralysis
+func EffectiveSpeed(p *models.BattlePokemon, side models.SideConditions, field *models.Field) int {
+	speed := p.GetStat("spe", false, false)
+
+	if p.HasItem("choicescarf") && !field.MagicRoom {
+		speed = FloorDiv(speed*3, 2)
+	}
+	if weathers, ok := weatherSpeedAbilities[data.ToID(p.Ability)]; ok {
+		for _, w := range weathers {
+			if field.Weather == w {
+				speed *= 2
+				break
+			}
+		}
+	}
+	if side.Tailwind {
+		speed *= 2
+	}
+
+	if p.Status != "" && p.HasAbility("quickfeet") {
+		speed = FloorDiv(speed*3, 2)
+	} else if p.IsParalyzed() {
+		if field.Generation >= 7 {
+			speed = FloorDiv(speed, 2)
+		} else {
+			speed = FloorDiv(speed, 4)
+		}
+	}
+
+	return speed
+}
+
+// applyAutoField sets weather and terrain from the attacker's and defender's switch-in
+// abilities and applies Intimidate and Download boosts
+// Manually set weather or terrain is left alone
+func (c *Calculator) applyAutoField(req *CalculateRequest) *models.AutoFieldResult {
+	attacker := req.Attacker
+	defender := req.Defender
+	field := req.Field
+	result := &models.AutoFieldResult{}
+
+	// Both Pokemon switch in together: the faster one's ability activates first,
+	// so the slower one's weather or terrain is the one that stays
+	attackerSpeed := EffectiveSpeed(attacker, field.AttackerSide, field)
+	defenderSpeed := EffectiveSpeed(defender, field.DefenderSide, field)
+	order := []*models.BattlePokemon{attacker, defender}
+	if defenderSpeed > attackerSpeed {
+		order = []*models.BattlePokemon{defender, attacker}
+	}
+
+	manualWeather := field.Weather != ""
+	manualTerrain := field.Terrain != ""
+	for _, p := range order {
+		c.applyFieldAbility(p, field, manualWeather, manualTerrain, result)
+	}
+
+	if attackerSpeed == defenderSpeed && conflicting(attacker, defender, field.Generation) {
+		result.Notes = append(result.Notes, "Speed tie: the field depends on which ability activates last")
+	}
+
+	// Intimidate and Download
+	for _, pair := range [][2]*models.BattlePokemon{{attacker, defender}, {defender, attacker}} {
+		applySwitchInBoosts(pair[0], pair[1], field.Generation, result)
+	}
+
+	return result
+}
+
+// applyFieldAbility applies one Pokemon's weather or terrain ability to the field
+func (c *Calculator) applyFieldAbility(p *models.BattlePokemon, field *models.Field, manualWeather, manualTerrain bool, result *models.AutoFieldResult) {
+	abilityID := data.ToID(p.Ability)
+	if abilityID == "" || field.Generation < fieldAbilityGeneration[abilityID] {
+		return
+	}
+	source := fmt.Sprintf("%s's %s", pokemonName(p), abilityName(p))
+
+	if weather, ok := data.WeatherAbilities[abilityID]; ok {
+		// Snow Warning summoned hail before Gen 9
+		if weather == "snow" && field.Generation < 9 {
+			weather = "hail"
+		}
+		switch {
+		case manualWeather:
+			result.Notes = append(result.Notes, fmt.Sprintf("%s ignored (weather set manually)", source))
+		case strongWeathers[field.Weather] && !strongWeathers[weather]:
+			result.Notes = append(result.Notes, fmt.Sprintf("%s fails (%s)", source, result.WeatherSource))
+		default:
+			if result.WeatherSource != "" {
+				result.Notes = append(result.Notes, fmt.Sprintf("%s replaced %s (slower Pokemon activates last)", source, result.WeatherSource))
+			}
+			field.Weather = weather
+			result.Weather = weather
+			result.WeatherSource = source
+		}
+	}
+
+	if terrain, ok := data.TerrainAbilities[abilityID]; ok {
+		switch {
+		case manualTerrain:
+			result.Notes = append(result.Notes, fmt.Sprintf("%s ignored (terrain set manually)", source))
+		default:
+			if result.TerrainSource != "" {
+				result.Notes = append(result.Notes, fmt.Sprintf("%s replaced %s (slower Pokemon activates last)", source, result.TerrainSource))
+			}
+			field.Terrain = terrain
+			result.Terrain = terrain
+			result.TerrainSource = source
+		}
+	}
+}
+
+// conflicting returns true if both Pokemon have a weather or terrain ability active in this generation
+func conflicting(a, b *models.BattlePokemon, generation int) bool {
+	setsField := func(p *models.BattlePokemon) bool {
+		id := data.ToID(p.Ability)
+		if id == "" || generation < fieldAbilityGeneration[id] {
+			return false
+		}
+		_, weather := data.WeatherAbilities[id]
+		_, terrain := data.TerrainAbilities[id]
+		return weather || terrain
+	}
+	return setsField(a) && setsField(b)
+}
+
+// applySwitchInBoosts applies the stat changes from source's Intimidate or Download to the field
+func applySwitchInBoosts(source, target *models.BattlePokemon, generation int, result *models.AutoFieldResult) {
+	name := fmt.Sprintf("%s's %s", pokemonName(source), abilityName(source))
+
+	switch {
+	case source.HasAbility("intimidate"):
+		applyIntimidate(name, source, target, generation, result)
+
+	case source.HasAbility("download") && generation >= 4:
+		// Raises Attack if the foe's Defense is lower than its Special Defense, otherwise Special Attack
+		stat := "spa"
+		if target.GetStat("def", false, false) < target.GetStat("spd", false, false) {
+			stat = "atk"
+		}
+		applied := boostStat(source, stat, 1, generation)
+		result.Boosts = append(result.Boosts, boostText(name, source, stat, applied))
+	}
+}
+
+// applyIntimidate lowers the target's Attack, accounting for abilities that block or react to it
+func applyIntimidate(name string, source, target *models.BattlePokemon, generation int, result *models.AutoFieldResult) {
+	targetAbility := data.ToID(target.Ability)
+
+	if target.HasVolatile("substitute") {
+		result.Boosts = append(result.Boosts, fmt.Sprintf("%s blocked by %s's Substitute", name, pokemonName(target)))
+		return
+	}
+	if gen, ok := intimidateImmuneAbilities[targetAbility]; ok && generation >= gen {
+		result.Boosts = append(result.Boosts, fmt.Sprintf("%s blocked by %s's %s", name, pokemonName(target), abilityName(target)))
+		return
+	}
+
+	// Guard Dog raises Attack instead
+	if targetAbility == "guarddog" && generation >= 9 {
+		applied := boostStat(target, "atk", 1, generation)
+		result.Boosts = append(result.Boosts, boostText(name+" (Guard Dog)", target, "atk", applied))
+		return
+	}
+
+	// Mirror Armor bounces the drop back
+	if targetAbility == "mirrorarmor" && generation >= 8 {
+		applied := boostStat(source, "atk", -1, generation)
+		result.Boosts = append(result.Boosts, boostText(name+" (Mirror Armor)", source, "atk", applied))
+		return
+	}
+
+	applied := boostStat(target, "atk", -1, generation)
+	result.Boosts = append(result.Boosts, boostText(name, target, "atk", applied))
+	if applied >= 0 {
+		return
+	}
+
+	// Reactions to the drop
+	switch {
+	case targetAbility == "defiant" && generation >= 5:
+		result.Boosts = append(result.Boosts, boostText(abilityName(target), target, "atk", boostStat(target, "atk", 2, generation)))
+	case targetAbility == "competitive" && generation >= 6:
+		result.Boosts = append(result.Boosts, boostText(abilityName(target), target, "spa", boostStat(target, "spa", 2, generation)))
+	}
+	if targetAbility == "rattled" && generation >= 8 {
+		result.Boosts = append(result.Boosts, boostText(abilityName(target), target, "spe", boostStat(target, "spe", 1, generation)))
+	}
+}
+
+// boostStat changes a stat stage, applying Contrary and Simple
+func boostStat(p *models.BattlePokemon, stat string, delta, generation int) int {
+	if generation >= 5 && p.HasAbility("contrary") {
+		delta = -delta
+	}
+	if generation >= 4 && p.HasAbility("simple") {
+		delta *= 2
+	}
+	return p.Boosts.AddBoost(stat, delta)
+}
+
+// boostText describes a stat change, e.g. "Gyarados's Intimidate: Garchomp -1 Atk"
+func boostText(source string, target *models.BattlePokemon, stat string, applied int) string {
+	statNames := map[string]string{"atk": "Atk", "def": "Def", "spa": "SpA", "spd": "SpD", "spe": "Spe"}
+	if applied == 0 {
+		return fmt.Sprintf("%s: %s's %s won't go any further", source, pokemonName(target), statNames[stat])
+	}
+	return fmt.Sprintf("%s: %s %+d %s", source, pokemonName(target), applied, statNames[stat])
+}
+
+// pokemonName returns the display name of a Pokemon
+func pokemonName(p *models.BattlePokemon) string {
+	if p.SpeciesData != nil {
+		return p.SpeciesData.Name
+	}
+	return p.Species
+}
+
+// abilityName returns the display name of a Pokemon's ability
+func abilityName(p *models.BattlePokemon) string {
+	if p.AbilityData != nil {
+		return p.AbilityData.Name
+	}
+	return p.Ability
+}
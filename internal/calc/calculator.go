@@ -22,6 +22,15 @@ type CalculateRequest struct {
 	Move       *models.BattleMove    `json:"move"`
 	Field      *models.Field         `json:"field"`
 	Generation int                   `json:"generation"` // 3 = Gen 3, 5+ = Gen 5+ mechanics
+
+	// Reactive moves (Counter, Mirror Coat, Metal Burst, Bide): damage the attacker took
+	IncomingDamage int `json:"incomingDamage,omitempty"`
+
+	// Beat Up: the attacker's party (including the attacker)
+	AttackerParty []*models.PartyMember `json:"attackerParty,omitempty"`
+
+	// Set weather/terrain from switch-in abilities and apply Intimidate/Download
+	AutoField bool `json:"autoField,omitempty"`
 }
 
 // Calculate performs a damage calculation
@@ -41,27 +50,27 @@ func (c *Calculator) Calculate(req *CalculateRequest) *models.DamageResult {
 		req.Field.Generation = req.Generation
 	}
 
+	// Resolve switch-in abilities (Drizzle, Electric Surge, Intimidate, etc.)
+	var autoField *models.AutoFieldResult
+	if req.AutoField {
+		autoField = c.applyAutoField(req)
+	}
+
 	// Check for status moves
 	if req.Move.IsStatus() {
 		return &models.DamageResult{
 			Damages:     []int{0},
+			AutoField:   autoField,
 			Description: "Status moves deal no damage",
 		}
 	}
 
-	// Use appropriate formula based on generation
-	var damages []int
-	var factors []string
-
-	if req.Field.IsGen3() {
-		damages, factors = c.calculateGen3(req)
-	} else {
-		damages, factors = c.calculateGen5Plus(req)
-	}
+	damages, factors := c.calculateDamages(req)
 
 	// Build result
 	result := models.NewDamageResult(damages, req.Defender.GetMaxHP())
 	result.Factors = factors
+	result.AutoField = autoField
 
 	// Set multi-hit info if applicable
 	minHits, maxHits := req.Move.GetMultihit()
@@ -69,22 +78,11 @@ func (c *Calculator) Calculate(req *CalculateRequest) *models.DamageResult {
 		result.SetMultiHit(minHits, maxHits, req.Defender.GetMaxHP())
 	}
 
-	// Calculate KO chance
-	result.CalculateKO(req.Defender.GetCurrentHP(), req.Defender.GetMaxHP())
-
-	// Calculate recoil
-	if num, denom, hasRecoil := req.Move.GetRecoil(); hasRecoil {
-		result.CalculateRecoil(req.Attacker.GetMaxHP(), num, denom)
-	}
+	// Calculate KO chance (including Focus Sash, Sturdy, berries, etc.)
+	c.calculateKO(req, result)
 
-	// Life Orb recoil
-	if req.Attacker.HasItem("lifeorb") && !req.Attacker.HasAbility("sheerforce") {
-		recoilDamage := req.Attacker.GetMaxHP() / 10
-		result.Recoil = &models.RecoilResult{
-			Damage:  recoilDamage,
-			Percent: float64(recoilDamage) / float64(req.Attacker.GetMaxHP()) * 100,
-		}
-	}
+	// Calculate recoil (move recoil, Struggle, Life Orb) and crash damage per roll
+	c.applyRecoil(req, result)
 
 	// Calculate recovery for drain moves
 	if num, denom, hasDrain := req.Move.GetDrain(); hasDrain {
@@ -97,6 +95,29 @@ func (c *Calculator) Calculate(req *CalculateRequest) *models.DamageResult {
 	return result
 }
 
+// calculateDamages returns the damage rolls for the move, picking the right calculation
+// for fixed damage, reactive and variable power moves before falling back to the formula
+func (c *Calculator) calculateDamages(req *CalculateRequest) ([]int, []string) {
+	if damages, factors, ok := c.calculateFixedDamage(req); ok {
+		return damages, factors
+	}
+	if damages, factors, ok := c.calculateReactiveDamage(req); ok {
+		return damages, factors
+	}
+	if damages, factors, ok := c.calculateVariableDamage(req); ok {
+		return damages, factors
+	}
+	return c.calculateFormula(req)
+}
+
+// calculateFormula runs the regular damage formula for the field's generation
+func (c *Calculator) calculateFormula(req *CalculateRequest) ([]int, []string) {
+	if req.Field.IsGen3() {
+		return c.calculateGen3(req)
+	}
+	return c.calculateGen5Plus(req)
+}
+
 // getAttackStat returns the attack stat to use (Atk or SpA)
 func (c *Calculator) getAttackStat(attacker *models.BattlePokemon, move *models.BattleMove, field *models.Field) (int, string) {
 	var stat int
@@ -0,0 +1,176 @@
+package calc
+
+import (
+	"fmt"
+
+	"nuzlocke/internal/data"
+	"nuzlocke/internal/models"
+)
+
+// halfHPMoves deal damage equal to half of the target's current HP
+var halfHPMoves = map[string]bool{
+	"superfang":      true,
+	"naturesmadness": true,
+	"ruination":      true,
+}
+
+// isFixedDamageMove returns true if the move's damage doesn't come from the damage formula
+func isFixedDamageMove(move *models.BattleMove) bool {
+	if move.MoveData == nil || move.BasePower > 0 {
+		return false
+	}
+	if _, _, ok := move.MoveData.GetFixedDamage(); ok {
+		return true
+	}
+	if move.MoveData.IsOHKO() {
+		return true
+	}
+	switch id := move.GetID(); {
+	case halfHPMoves[id], id == "endeavor", id == "finalgambit", id == "psywave":
+		return true
+	}
+	return false
+}
+
+// calculateFixedDamage calculates damage for fixed and HP-based damage moves
+// (Seismic Toss, Dragon Rage, Super Fang, Endeavor, Psywave, OHKO moves, etc.)
+// Returns ok=false if the move uses the regular damage formula
+func (c *Calculator) calculateFixedDamage(req *CalculateRequest) ([]int, []string, bool) {
+	attacker := req.Attacker
+	defender := req.Defender
+	move := req.Move
+	field := req.Field
+
+	if !isFixedDamageMove(move) {
+		return nil, nil, false
+	}
+
+	var factors []string
+	if field.IsGen3() {
+		factors = append(factors, "Gen 3 mechanics")
+	}
+
+	// Fixed damage moves still respect type immunities (Seismic Toss vs Ghost, Night Shade vs Normal)
+	if reason := c.fixedDamageImmunity(attacker, defender, move, field); reason != "" {
+		return []int{0}, append(factors, reason), true
+	}
+
+	moveID := move.GetID()
+
+	// Seismic Toss, Night Shade, Dragon Rage, Sonic Boom
+	if amount, usesLevel, ok := move.MoveData.GetFixedDamage(); ok {
+		if usesLevel {
+			return []int{attacker.Level}, append(factors, "Damage equals user's level"), true
+		}
+		return []int{amount}, append(factors, fmt.Sprintf("Fixed %d damage", amount)), true
+	}
+
+	// OHKO moves (Fissure, Guillotine, Horn Drill, Sheer Cold)
+	if move.MoveData.IsOHKO() {
+		return c.calculateOHKO(attacker, defender, move, field, factors)
+	}
+
+	switch {
+	case halfHPMoves[moveID]:
+		damage := Max(FloorDiv(defender.GetCurrentHP(), 2), 1)
+		return []int{damage}, append(factors, "Half of target's current HP"), true
+
+	case moveID == "endeavor":
+		damage := defender.GetCurrentHP() - attacker.GetCurrentHP()
+		if damage <= 0 {
+			return []int{0}, append(factors, "Endeavor fails (target HP not higher than user's)"), true
+		}
+		return []int{damage}, append(factors, "Target HP lowered to user's HP"), true
+
+	case moveID == "finalgambit":
+		return []int{attacker.GetCurrentHP()}, append(factors, "Damage equals user's current HP"), true
+
+	case moveID == "psywave":
+		return psywaveDamages(attacker.Level, field.Generation), append(factors, "Psywave (random 0.5x-1.5x level)"), true
+	}
+
+	return nil, nil, false
+}
+
+// calculateOHKO calculates damage for one-hit KO moves
+func (c *Calculator) calculateOHKO(attacker, defender *models.BattlePokemon, move *models.BattleMove, field *models.Field, factors []string) ([]int, []string, bool) {
+	// Fails against higher-level targets
+	if defender.Level > attacker.Level {
+		return []int{0}, append(factors, "OHKO fails (target is higher level)"), true
+	}
+
+	// Sturdy blocks OHKO moves in every generation
+	if defender.HasAbility("sturdy") {
+		return []int{0}, append(factors, "Sturdy (OHKO immunity)"), true
+	}
+
+	// Gen 7+: Ice types are immune to Sheer Cold
+	immuneType := move.MoveData.GetOHKOImmuneType()
+	if immuneType != "" && field.Generation >= 7 && defender.HasType(immuneType) {
+		return []int{0}, append(factors, fmt.Sprintf("%s types are immune", immuneType)), true
+	}
+
+	// Accuracy: user's level - target's level + 30 (20 for Sheer Cold from a non-Ice user in Gen 7+)
+	baseAccuracy := 30
+	if immuneType != "" && field.Generation >= 7 && !attacker.HasType(immuneType) {
+		baseAccuracy = 20
+	}
+	accuracy := Min(attacker.Level-defender.Level+baseAccuracy, 100)
+	factors = append(factors, fmt.Sprintf("OHKO (%d%% accuracy)", accuracy))
+
+	return []int{defender.GetMaxHP()}, factors, true
+}
+
+// fixedDamageImmunity returns a reason if the defender is immune to a fixed damage move
+func (c *Calculator) fixedDamageImmunity(attacker, defender *models.BattlePokemon, move *models.BattleMove, field *models.Field) string {
+	moveType := move.GetType()
+
+	// The Fairy type doesn't exist before Gen 6
+	defenderTypes := defender.Types
+	if field.Generation < 6 {
+		defenderTypes = make([]string, 0, len(defender.Types))
+		for _, t := range defender.Types {
+			if t != "Fairy" {
+				defenderTypes = append(defenderTypes, t)
+			}
+		}
+		if len(defenderTypes) == 0 {
+			defenderTypes = []string{"Normal"}
+		}
+	}
+
+	typeEff := c.Store.GetTypeEffectivenessMultiple(moveType, defenderTypes)
+	if typeEff == 0 {
+		return "Immune"
+	}
+
+	// Type immunity abilities (Levitate vs Fissure, Volt Absorb, etc.)
+	if defender.Ability != "" && !attacker.HasAbility("moldbreaker") {
+		if immuneType, ok := data.TypeImmunityAbilities[data.ToID(defender.Ability)]; ok && immuneType == moveType {
+			return "Immune (" + defender.Ability + ")"
+		}
+		// Wonder Guard only lets super effective moves through
+		if defender.HasAbility("wonderguard") && typeEff <= 1 {
+			return "Immune (Wonder Guard)"
+		}
+	}
+
+	return ""
+}
+
+// psywaveDamages returns every possible Psywave damage value in ascending order
+// Gen 3-4: level * (10r + 50) / 100 for r in 0-10
+// Gen 5+: level * (r + 50) / 100 for r in 0-100
+func psywaveDamages(level, generation int) []int {
+	var damages []int
+	if generation < 5 {
+		for r := 0; r <= 10; r++ {
+			damages = append(damages, Max(FloorDiv(level*(10*r+50), 100), 1))
+		}
+		return damages
+	}
+	for r := 0; r <= 100; r++ {
+		damages = append(damages, Max(FloorDiv(level*(r+50), 100), 1))
+	}
+	return damages
+}
@@ -60,6 +60,9 @@ func (c *Calculator) calculateGen3(req *CalculateRequest) ([]int, []string) {
 
 	// Apply modifiers in Gen 3 order
 	baseDamage = c.applyGen3Modifiers(baseDamage, attacker, defender, move, field, &factors)
+	if c.getTypeEffectiveness(move, defender) == 0 {
+		return []int{0}, factors // Immune (rolls would otherwise floor to 1)
+	}
 
 	// Calculate damage rolls (85-100%)
 	// Gen 3 uses the same 85-100% random factor
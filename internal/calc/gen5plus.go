@@ -279,8 +279,8 @@ func (c *Calculator) applyWeatherModifiers(chain *ModifierChain, move *models.Ba
 
 // applyItemModifiers adds item-based damage modifiers
 func (c *Calculator) applyItemModifiers(chain *ModifierChain, attacker, defender *models.BattlePokemon, move *models.BattleMove, typeEff float64, factors *[]string) {
-	// Life Orb
-	if attacker.HasItem("lifeorb") && !attacker.HasAbility("sheerforce") {
+	// Life Orb (Sheer Force suppresses it only for moves with secondary effects)
+	if attacker.HasItem("lifeorb") && !(attacker.HasAbility("sheerforce") && move.HasSecondaryEffect()) {
 		chain.Add(ModLifeOrb, "Life Orb")
 		*factors = append(*factors, "Life Orb")
 	}
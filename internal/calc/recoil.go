@@ -0,0 +1,149 @@
+package calc
+
+import (
+	"fmt"
+
+	"nuzlocke/internal/models"
+)
+
+// applyRecoil calculates recoil and crash damage for every damage roll
+// Move recoil, Struggle recoil, Mind Blown recoil and Life Orb stack; crash damage is reported separately
+func (c *Calculator) applyRecoil(req *CalculateRequest, result *models.DamageResult) {
+	attacker := req.Attacker
+	move := req.Move
+	field := req.Field
+
+	attackerHP := attacker.GetCurrentHP()
+	attackerMaxHP := attacker.GetMaxHP()
+	defenderHP := req.Defender.GetCurrentHP()
+	magicGuard := attacker.HasAbility("magicguard")
+
+	// Move recoil (Double-Edge, Brave Bird, etc.) - blocked by Rock Head and Magic Guard
+	num, denom, hasRecoil := move.GetRecoil()
+	if hasRecoil && (attacker.HasAbility("rockhead") || magicGuard) {
+		hasRecoil = false
+		if attacker.HasAbility("rockhead") {
+			result.AddFactor("Rock Head (no recoil)")
+		} else {
+			result.AddFactor("Magic Guard (no recoil)")
+		}
+	}
+
+	// Struggle recoil ignores both Rock Head and Magic Guard
+	struggle := move.MoveData != nil && move.MoveData.StruggleRecoil
+
+	// Mind Blown / Steel Beam - blocked only by Magic Guard
+	mindBlown := move.MoveData != nil && move.MoveData.MindBlownRecoil && !magicGuard
+
+	// Life Orb (Gen 4+) - suppressed by Sheer Force when the move has a secondary effect
+	lifeOrb := field.Generation >= 4 && attacker.HasItem("lifeorb") && !magicGuard &&
+		!(attacker.HasAbility("sheerforce") && move.HasSecondaryEffect())
+
+	// Explosion, Self-Destruct, Final Gambit, Memento - the user faints
+	selfDestruct := ""
+	if move.MoveData != nil {
+		selfDestruct = move.MoveData.SelfDestruct
+	}
+
+	var sources []string
+	if selfDestruct != "" {
+		sources = append(sources, move.MoveData.Name)
+	}
+	if hasRecoil {
+		sources = append(sources, fmt.Sprintf("%d/%d recoil", num, denom))
+	}
+	if struggle {
+		sources = append(sources, "Struggle")
+	}
+	if mindBlown {
+		sources = append(sources, move.MoveData.Name)
+	}
+	if lifeOrb {
+		sources = append(sources, "Life Orb")
+	}
+
+	if len(sources) > 0 {
+		rolls := make([]int, len(result.Damages))
+		for i, dmg := range result.Damages {
+			// Recoil is based on the HP the target actually lost
+			dealt := Min(dmg, defenderHP)
+
+			recoil := 0
+			if selfDestruct == "always" || (selfDestruct != "" && dealt > 0) {
+				rolls[i] = attackerHP
+				continue
+			}
+			if hasRecoil && dealt > 0 {
+				recoil += recoilFromDamage(dealt, num, denom, field.Generation)
+			}
+			if struggle {
+				if field.Generation <= 3 {
+					// Gen 2-3: 1/4 of the damage dealt
+					if dealt > 0 {
+						recoil += Max(FloorDiv(dealt, 4), 1)
+					}
+				} else {
+					// Gen 4+: 1/4 of max HP, rounded half up
+					recoil += Max(FloorDiv(attackerMaxHP+2, 4), 1)
+				}
+			}
+			if mindBlown {
+				recoil += Max(FloorDiv(attackerMaxHP+1, 2), 1)
+			}
+			if lifeOrb && dealt > 0 {
+				recoil += Max(FloorDiv(attackerMaxHP, 10), 1)
+			}
+			rolls[i] = recoil
+		}
+		result.Recoil = models.NewRecoilResult(rolls, attackerHP, attackerMaxHP, sources)
+	}
+
+	// Crash damage (Jump Kick / High Jump Kick) when the move misses
+	if move.MoveData != nil && move.MoveData.HasCrashDamage && !magicGuard {
+		result.Crash = c.crashDamage(req, result.Damages)
+	}
+}
+
+// recoilFromDamage returns the recoil taken from dealing the given damage
+// Gen 5+ rounds half up; earlier generations round down. Recoil is always at least 1
+func recoilFromDamage(dealt, num, denom, generation int) int {
+	var recoil int
+	if generation >= 5 {
+		recoil = FloorDiv(2*dealt*num+denom, 2*denom)
+	} else {
+		recoil = FloorDiv(dealt*num, denom)
+	}
+	return Max(recoil, 1)
+}
+
+// crashDamage calculates the damage taken when a crash move misses
+// Gen 5+: 1/2 of the user's max HP
+// Gen 4: 1/2 of the damage it would have dealt, capped at 1/2 of the target's max HP
+// Gen 3: 1/8 of the damage it would have dealt, capped at 1/2 of the target's max HP
+func (c *Calculator) crashDamage(req *CalculateRequest, damages []int) *models.RecoilResult {
+	attackerHP := req.Attacker.GetCurrentHP()
+	attackerMaxHP := req.Attacker.GetMaxHP()
+	generation := req.Field.Generation
+	sources := []string{"Crash damage"}
+
+	if generation >= 5 {
+		crash := Max(FloorDiv(attackerMaxHP, 2), 1)
+		return models.NewRecoilResult([]int{crash}, attackerHP, attackerMaxHP, sources)
+	}
+
+	defenderMaxHP := req.Defender.GetMaxHP()
+	divisor := 8
+	if generation == 4 {
+		divisor = 2
+	}
+
+	rolls := make([]int, len(damages))
+	for i, dmg := range damages {
+		// If the move would have dealt no damage, the target's max HP is used instead
+		if dmg == 0 {
+			dmg = defenderMaxHP
+		}
+		rolls[i] = Clamp(FloorDiv(dmg, divisor), 1, Max(FloorDiv(defenderMaxHP, 2), 1))
+	}
+	return models.NewRecoilResult(rolls, attackerHP, attackerMaxHP, sources)
+}
@@ -0,0 +1,125 @@
+package calc
+
+import (
+	"nuzlocke/internal/models"
+)
+
+// calculateKO fills in the KO chance, accounting for the defender's survival items and abilities
+func (c *Calculator) calculateKO(req *CalculateRequest, result *models.DamageResult) {
+	mechanics := c.buildSurvivalMechanics(req)
+	if mechanics == nil {
+		result.CalculateKO(req.Defender.GetCurrentHP(), req.Defender.GetMaxHP())
+		return
+	}
+	result.CalculateKOWithSurvival(mechanics)
+}
+
+// buildSurvivalMechanics collects the defender's survival mechanics for the KO simulation
+// Returns nil if none apply, so the plain HP-based KO calculation can be used
+func (c *Calculator) buildSurvivalMechanics(req *CalculateRequest) *models.SurvivalMechanics {
+	attacker := req.Attacker
+	defender := req.Defender
+	move := req.Move
+	field := req.Field
+	gen := field.Generation
+
+	m := &models.SurvivalMechanics{
+		MaxHP:      defender.GetMaxHP(),
+		CurrentHP:  defender.GetCurrentHP(),
+		HitsPerUse: 1,
+	}
+	applies := false
+
+	// Multi-hit moves strike several times per use (assume the minimum unless Skill Link)
+	if minHits, maxHits := move.GetMultihit(); maxHits > 1 {
+		m.HitsPerUse = minHits
+		if attacker.HasAbility("skilllink") {
+			m.HitsPerUse = maxHits
+		}
+		applies = true
+	}
+
+	// Mold Breaker and friends ignore the defender's abilities
+	ignoresAbility := attacker.HasAbility("moldbreaker") || attacker.HasAbility("teravolt") || attacker.HasAbility("turboblaze")
+
+	// Defender abilities
+	if !ignoresAbility {
+		if defender.HasAbility("sturdy") && gen >= 5 {
+			m.Sturdy = true
+			applies = true
+		}
+		if defender.HasAbility("disguise") && gen >= 7 {
+			m.Disguise = true
+			m.DisguiseName = "Disguise"
+			if gen >= 8 {
+				m.DisguiseChip = FloorDiv(m.MaxHP, 8)
+			}
+			applies = true
+		}
+		if defender.HasAbility("iceface") && gen >= 8 && move.IsPhysical() {
+			m.Disguise = true
+			m.DisguiseName = "Ice Face"
+			applies = true
+		}
+
+		// Multiscale / Shadow Shield break after the first hit
+		if gen >= 5 && defender.IsAtFullHP() && (defender.HasAbility("multiscale") || defender.HasAbility("shadowshield")) {
+			m.DamagedRolls = c.damagedDefenderRolls(req)
+			applies = true
+		}
+	}
+
+	// Defender items
+	if item := defender.ItemData; item != nil && !field.MagicRoom && !defender.HasAbility("klutz") {
+		switch {
+		case item.ID == "focussash" && gen >= 4:
+			m.FocusSash = true
+			applies = true
+
+		case item.ID == "focusband":
+			m.FocusBand = true
+			applies = true
+
+		case item.ID == "sitrusberry":
+			m.HealBerry = item.Name
+			m.HealThreshold = FloorDiv(m.MaxHP, 2)
+			if gen <= 3 {
+				m.HealAmount = 30 // Gen 3: fixed 30 HP
+			} else {
+				m.HealAmount = FloorDiv(m.MaxHP, 4)
+			}
+			applies = true
+
+		case item.ID == "oranberry":
+			m.HealBerry = item.Name
+			m.HealThreshold = FloorDiv(m.MaxHP, 2)
+			m.HealAmount = 10
+			applies = true
+
+		case item.GetResistBerryType() != "" && gen >= 4:
+			// Chilan Berry works on any Normal hit; the rest need a super effective hit
+			berryType := item.GetResistBerryType()
+			if berryType == move.GetType() && (berryType == "Normal" || c.getTypeEffectiveness(move, defender) > 1) {
+				m.ResistBerry = item.Name
+				applies = true
+			}
+		}
+	}
+
+	if !applies {
+		return nil
+	}
+	return m
+}
+
+// damagedDefenderRolls recalculates the damage rolls as if the defender were below full HP
+func (c *Calculator) damagedDefenderRolls(req *CalculateRequest) []int {
+	defender := *req.Defender
+	defender.CurrentHP = defender.GetMaxHP() - 1
+	defender.CurrentHPPercent = 0
+
+	sub := *req
+	sub.Defender = &defender
+	damages, _ := c.calculateDamages(&sub)
+	return damages
+}
@@ -0,0 +1,241 @@
+package calc
+
+import (
+	"fmt"
+	"sort"
+
+	"nuzlocke/internal/models"
+)
+
+// weightedPower is a possible base power of a random-power move and its relative weight
+type weightedPower struct {
+	BasePower int
+	Weight    int
+}
+
+// Magnitude: 5% 10, 10% 30, 20% 50, 30% 70, 20% 90, 10% 110, 5% 150 (weights out of 20)
+var magnitudePowers = []weightedPower{
+	{10, 1}, {30, 2}, {50, 4}, {70, 6}, {90, 4}, {110, 2}, {150, 1},
+}
+
+// Present: 40% 40, 30% 80, 10% 120, 20% heals the target (weights out of 10)
+// A base power of 0 represents the heal
+var presentPowers = []weightedPower{
+	{40, 4}, {80, 3}, {120, 1}, {0, 2},
+}
+
+// reactiveMoves maps moves that return damage taken to their multiplier [numerator, denominator]
+var reactiveMoves = map[string][2]int{
+	"counter":    {2, 1},
+	"mirrorcoat": {2, 1},
+	"metalburst": {3, 2},
+	"bide":       {2, 1},
+}
+
+// calculateVariableDamage handles moves whose base power or type is decided at runtime
+// (Magnitude, Present, Beat Up, Fling, Natural Gift)
+// Returns ok=false if the move uses the regular damage formula
+func (c *Calculator) calculateVariableDamage(req *CalculateRequest) ([]int, []string, bool) {
+	move := req.Move
+	if move.MoveData == nil || move.BasePower > 0 {
+		return nil, nil, false
+	}
+
+	switch move.GetID() {
+	case "magnitude":
+		damages, factors := c.calculateWeightedPowers(req, magnitudePowers)
+		return damages, append(factors, "Magnitude (random power 10-150)"), true
+
+	case "present":
+		damages, factors := c.calculateWeightedPowers(req, presentPowers)
+		return damages, append(factors, "Present (20% chance to heal the target)"), true
+
+	case "beatup":
+		damages, factors := c.calculateBeatUp(req)
+		return damages, factors, true
+
+	case "fling":
+		item := req.Attacker.ItemData
+		if item == nil || req.Attacker.HasAbility("klutz") || req.Field.MagicRoom {
+			return []int{0}, []string{"Fling fails (no usable item)"}, true
+		}
+		basePower := item.FlingBasePower
+		if basePower == 0 && item.NaturalGift != nil {
+			basePower = 10 // Berries
+		}
+		if basePower == 0 {
+			return []int{0}, []string{"Fling fails (" + item.Name + " can't be flung)"}, true
+		}
+		damages, factors := c.calculateWithPower(req, basePower, "")
+		return damages, append(factors, fmt.Sprintf("Fling %s (%d BP)", item.Name, basePower)), true
+
+	case "naturalgift":
+		item := req.Attacker.ItemData
+		if item == nil || item.NaturalGift == nil || req.Attacker.HasAbility("klutz") || req.Field.MagicRoom {
+			return []int{0}, []string{"Natural Gift fails (no Berry)"}, true
+		}
+		basePower := item.NaturalGift.BasePower
+		if req.Field.Generation <= 5 {
+			basePower -= 20 // Berry powers were raised by 20 in Gen 6
+		}
+		damages, factors := c.calculateWithPower(req, basePower, item.NaturalGift.Type)
+		return damages, append(factors, fmt.Sprintf("Natural Gift %s (%s, %d BP)", item.Name, item.NaturalGift.Type, basePower)), true
+	}
+
+	return nil, nil, false
+}
+
+// calculateReactiveDamage handles moves that return damage the user took
+// (Counter, Mirror Coat, Metal Burst, Bide) using the request's incoming damage
+// Returns ok=false for other moves
+func (c *Calculator) calculateReactiveDamage(req *CalculateRequest) ([]int, []string, bool) {
+	move := req.Move
+	if move.MoveData == nil || move.BasePower > 0 {
+		return nil, nil, false
+	}
+
+	moveID := move.GetID()
+	ratio, ok := reactiveMoves[moveID]
+	if !ok {
+		return nil, nil, false
+	}
+
+	var factors []string
+	if req.Field.IsGen3() {
+		factors = append(factors, "Gen 3 mechanics")
+	}
+
+	if req.IncomingDamage <= 0 {
+		return []int{0}, append(factors, move.MoveData.Name+" fails (no damage taken)"), true
+	}
+
+	// Bide ignores type immunities; the others don't
+	if moveID != "bide" {
+		if reason := c.fixedDamageImmunity(req.Attacker, req.Defender, move, req.Field); reason != "" {
+			return []int{0}, append(factors, reason), true
+		}
+	}
+
+	damage := Max(FloorDiv(req.IncomingDamage*ratio[0], ratio[1]), 1)
+	factors = append(factors, fmt.Sprintf("%s (%d damage taken)", move.MoveData.Name, req.IncomingDamage))
+	return []int{damage}, factors, true
+}
+
+// calculateWithPower runs the damage formula with an overridden base power and (optionally) type
+func (c *Calculator) calculateWithPower(req *CalculateRequest, basePower int, moveType string) ([]int, []string) {
+	move := *req.Move
+	move.BasePower = basePower
+	if moveType != "" {
+		move.Type = moveType
+	}
+
+	sub := *req
+	sub.Move = &move
+	return c.calculateFormula(&sub)
+}
+
+// calculateWeightedPowers returns a probability-weighted roll set for a random-power move
+// Each base power's 16 rolls are repeated according to its weight so that
+// roll counts reflect the real odds
+func (c *Calculator) calculateWeightedPowers(req *CalculateRequest, powers []weightedPower) ([]int, []string) {
+	var damages []int
+	var factors []string
+
+	for _, wp := range powers {
+		rolls := make([]int, 16)
+		if wp.BasePower > 0 {
+			var rollFactors []string
+			rolls, rollFactors = c.calculateWithPower(req, wp.BasePower, "")
+			if factors == nil {
+				factors = rollFactors
+			}
+			// Immune targets return a single 0 roll; pad it so weights stay balanced
+			if len(rolls) == 1 {
+				rolls = []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+			}
+		}
+		for i := 0; i < wp.Weight; i++ {
+			damages = append(damages, rolls...)
+		}
+	}
+
+	sort.Ints(damages)
+	return damages, factors
+}
+
+// calculateBeatUp calculates Beat Up damage summed over every eligible party member
+// Hits are added roll-for-roll, so the range is exact but the KO odds are approximate
+func (c *Calculator) calculateBeatUp(req *CalculateRequest) ([]int, []string) {
+	var members []*models.PartyMember
+	for _, member := range req.AttackerParty {
+		if member == nil || !member.CanBeatUp() {
+			continue
+		}
+		// Look up species data on a copy so the caller's party is left untouched
+		local := *member
+		local.SpeciesData = c.Store.GetPokemon(member.Species)
+		if local.SpeciesData != nil {
+			members = append(members, &local)
+		}
+	}
+
+	// Without a party, only the user attacks
+	if len(members) == 0 && req.Attacker.SpeciesData != nil {
+		members = append(members, &models.PartyMember{
+			Species:     req.Attacker.Species,
+			Level:       req.Attacker.Level,
+			CurrentHP:   req.Attacker.GetCurrentHP(),
+			SpeciesData: req.Attacker.SpeciesData,
+		})
+	}
+
+	var factors []string
+	totals := make([]int, 16)
+
+	for _, member := range members {
+		var rolls []int
+		if !req.Field.IsGen5Plus() {
+			rolls = c.beatUpHitTypeless(req, member)
+		} else {
+			basePower := 5 + FloorDiv(member.SpeciesData.BaseStats.Atk, 10)
+			var hitFactors []string
+			rolls, hitFactors = c.calculateWithPower(req, basePower, "")
+			if factors == nil {
+				factors = hitFactors
+			}
+		}
+		for i := range totals {
+			if i < len(rolls) {
+				totals[i] += rolls[i]
+			} else {
+				totals[i] += rolls[0]
+			}
+		}
+	}
+
+	if !req.Field.IsGen5Plus() {
+		factors = append(factors, fmt.Sprintf("Gen %d mechanics", req.Field.Generation), "Typeless damage")
+	}
+	factors = append(factors, fmt.Sprintf("Beat Up (%d hits)", len(members)))
+	return totals, factors
+}
+
+// beatUpHitTypeless calculates one Gen 3-4 Beat Up hit
+// Each hit uses the party member's level and base Attack against the target's base Defense
+// with 10 base power, no STAB and no type effectiveness
+func (c *Calculator) beatUpHitTypeless(req *CalculateRequest, member *models.PartyMember) []int {
+	defense := 1
+	if req.Defender.SpeciesData != nil {
+		defense = Max(req.Defender.SpeciesData.BaseStats.Def, 1)
+	}
+
+	levelFactor := FloorDiv(2*member.Level, 5) + 2
+	baseDamage := FloorDiv(levelFactor*10*member.SpeciesData.BaseStats.Atk, defense)
+	baseDamage = FloorDiv(baseDamage, 50) + 2
+
+	if req.Move.IsCrit {
+		baseDamage *= 2
+	}
+
+	return AllDamageRolls(baseDamage)
+}
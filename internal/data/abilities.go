@@ -107,4 +107,14 @@ var WeatherAbilities = map[string]string{
 	"primordialsea": "heavyrain",
 	"desolateland": "harshsun",
 	"deltastream":  "strongwinds",
+	"orichalcumpulse": "sun",
+}
+
+// Terrain abilities
+var TerrainAbilities = map[string]string{
+	"electricsurge": "electric",
+	"grassysurge":   "grassy",
+	"mistysurge":    "misty",
+	"psychicsurge":  "psychic",
+	"hadronengine":  "electric",
 }
@@ -0,0 +1,266 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Encounter methods
+const (
+	MethodGrass     = "grass"
+	MethodSurf      = "surf"
+	MethodRockSmash = "rockSmash"
+	MethodOldRod    = "oldRod"
+	MethodGoodRod   = "goodRod"
+	MethodSuperRod  = "superRod"
+)
+
+// EncounterMethods lists every encounter method in display order
+var EncounterMethods = []string{MethodGrass, MethodSurf, MethodRockSmash, MethodOldRod, MethodGoodRod, MethodSuperRod}
+
+// EncounterSlot is one entry of a wild encounter table
+type EncounterSlot struct {
+	Species  string `json:"species"`
+	MinLevel int    `json:"minLevel"`
+	MaxLevel int    `json:"maxLevel"`
+	Rate     int    `json:"rate"` // Percent chance for this method
+}
+
+// LocationEncounters holds a map's wild encounter tables
+// Tables list every slot in game order: 12 grass, 5 surf and rock smash, and 2, 3 and 5 for the rods
+type LocationEncounters struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Grass     []EncounterSlot `json:"grass,omitempty"` // Includes cave floors and sand
+	Surf      []EncounterSlot `json:"surf,omitempty"`
+	RockSmash []EncounterSlot `json:"rockSmash,omitempty"`
+	OldRod    []EncounterSlot `json:"oldRod,omitempty"`
+	GoodRod   []EncounterSlot `json:"goodRod,omitempty"`
+	SuperRod  []EncounterSlot `json:"superRod,omitempty"`
+}
+
+// EncounterTable holds every location's encounters for one game
+// Hack overlays name a base game and only list the tables they change
+type EncounterTable struct {
+	Game      string                         `json:"game"`
+	Name      string                         `json:"name"`
+	Base      string                         `json:"base,omitempty"`
+	Locations map[string]*LocationEncounters `json:"locations"`
+}
+
+// PokemonLocation is a place a Pokemon can be found in the wild
+type PokemonLocation struct {
+	Location     string `json:"location"`
+	LocationName string `json:"locationName"`
+	Method       string `json:"method"`
+	MinLevel     int    `json:"minLevel"`
+	MaxLevel     int    `json:"maxLevel"`
+	Rate         int    `json:"rate"` // Combined percent chance of all the species' slots
+}
+
+// Slots returns the encounter table for a method
+func (l *LocationEncounters) Slots(method string) []EncounterSlot {
+	switch method {
+	case MethodGrass:
+		return l.Grass
+	case MethodSurf:
+		return l.Surf
+	case MethodRockSmash:
+		return l.RockSmash
+	case MethodOldRod:
+		return l.OldRod
+	case MethodGoodRod:
+		return l.GoodRod
+	case MethodSuperRod:
+		return l.SuperRod
+	}
+	return nil
+}
+
+// setSlots replaces the encounter table for a method
+func (l *LocationEncounters) setSlots(method string, slots []EncounterSlot) {
+	switch method {
+	case MethodGrass:
+		l.Grass = slots
+	case MethodSurf:
+		l.Surf = slots
+	case MethodRockSmash:
+		l.RockSmash = slots
+	case MethodOldRod:
+		l.OldRod = slots
+	case MethodGoodRod:
+		l.GoodRod = slots
+	case MethodSuperRod:
+		l.SuperRod = slots
+	}
+}
+
+// CombineSlots merges slots of the same species, adding their rates and widening the level range
+// Species are returned by descending rate
+func CombineSlots(slots []EncounterSlot) []EncounterSlot {
+	var combined []EncounterSlot
+	index := make(map[string]int)
+	for _, slot := range slots {
+		id := toID(slot.Species)
+		i, ok := index[id]
+		if !ok {
+			index[id] = len(combined)
+			combined = append(combined, slot)
+			continue
+		}
+		c := &combined[i]
+		c.Rate += slot.Rate
+		if slot.MinLevel < c.MinLevel {
+			c.MinLevel = slot.MinLevel
+		}
+		if slot.MaxLevel > c.MaxLevel {
+			c.MaxLevel = slot.MaxLevel
+		}
+	}
+	sort.SliceStable(combined, func(i, j int) bool {
+		return combined[i].Rate > combined[j].Rate
+	})
+	return combined
+}
+
+// loadEncounters loads every game's encounter tables from a directory
+// Overlays are applied on top of their base game
+func (s *Store) loadEncounters(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	tables := make(map[string]*EncounterTable)
+	for _, path := range files {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var table EncounterTable
+		if err := json.Unmarshal(raw, &table); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		if table.Game == "" {
+			table.Game = strings.TrimSuffix(filepath.Base(path), ".json")
+		}
+		table.Game = toID(table.Game)
+		tables[table.Game] = &table
+	}
+
+	for id, table := range tables {
+		resolved, err := resolveEncounterTable(table, tables, 0)
+		if err != nil {
+			return err
+		}
+		s.Encounters[id] = resolved
+	}
+	return nil
+}
+
+// resolveEncounterTable applies an overlay table on top of its base game's tables
+func resolveEncounterTable(table *EncounterTable, tables map[string]*EncounterTable, depth int) (*EncounterTable, error) {
+	if depth > len(tables) {
+		return nil, fmt.Errorf("encounter overlay %s: base games form a cycle", table.Game)
+	}
+
+	resolved := &EncounterTable{
+		Game:      table.Game,
+		Name:      table.Name,
+		Base:      table.Base,
+		Locations: make(map[string]*LocationEncounters),
+	}
+
+	if table.Base != "" {
+		base, ok := tables[toID(table.Base)]
+		if !ok {
+			return nil, fmt.Errorf("encounter overlay %s: unknown base game %s", table.Game, table.Base)
+		}
+		resolvedBase, err := resolveEncounterTable(base, tables, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		for id, loc := range resolvedBase.Locations {
+			copied := *loc
+			resolved.Locations[id] = &copied
+		}
+	}
+
+	// Overlay tables replace the base game's tables method by method
+	for key, loc := range table.Locations {
+		id := toID(key)
+		target, ok := resolved.Locations[id]
+		if !ok {
+			target = &LocationEncounters{}
+			resolved.Locations[id] = target
+		}
+		target.ID = id
+		if loc.Name != "" {
+			target.Name = loc.Name
+		}
+		for _, method := range EncounterMethods {
+			if slots := loc.Slots(method); slots != nil {
+				target.setSlots(method, slots)
+			}
+		}
+	}
+
+	return resolved, nil
+}
+
+// GetEncounterTable returns a game's encounter tables
+func (s *Store) GetEncounterTable(game string) *EncounterTable {
+	return s.Encounters[toID(game)]
+}
+
+// GetLocationEncounters returns a location's encounter tables in a game
+func (s *Store) GetLocationEncounters(game, location string) *LocationEncounters {
+	table := s.GetEncounterTable(game)
+	if table == nil {
+		return nil
+	}
+	return table.Locations[toID(location)]
+}
+
+// FindPokemonLocations returns every location and method a species can be encountered with in a game
+func (s *Store) FindPokemonLocations(game, species string) []PokemonLocation {
+	table := s.GetEncounterTable(game)
+	if table == nil {
+		return nil
+	}
+
+	speciesID := toID(species)
+	locations := []PokemonLocation{}
+	for _, loc := range table.Locations {
+		for _, method := range EncounterMethods {
+			for _, slot := range CombineSlots(loc.Slots(method)) {
+				if toID(slot.Species) != speciesID {
+					continue
+				}
+				locations = append(locations, PokemonLocation{
+					Location:     loc.ID,
+					LocationName: loc.Name,
+					Method:       method,
+					MinLevel:     slot.MinLevel,
+					MaxLevel:     slot.MaxLevel,
+					Rate:         slot.Rate,
+				})
+			}
+		}
+	}
+
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Rate != locations[j].Rate {
+			return locations[i].Rate > locations[j].Rate
+		}
+		if locations[i].LocationName != locations[j].LocationName {
+			return locations[i].LocationName < locations[j].LocationName
+		}
+		return locations[i].Method < locations[j].Method
+	})
+	return locations
+}
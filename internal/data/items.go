@@ -94,3 +94,34 @@ func (i *Item) GetTypeBoost() string {
 	}
 	return ""
 }
+
+// Type-resist berries halve damage from a super effective hit of their type (Gen 4+)
+// Chilan Berry halves any Normal-type hit
+var TypeResistBerries = map[string]string{
+	"occaberry":   "Fire",
+	"passhoberry": "Water",
+	"wacanberry":  "Electric",
+	"rindoberry":  "Grass",
+	"yacheberry":  "Ice",
+	"chopleberry": "Fighting",
+	"kebiaberry":  "Poison",
+	"shucaberry":  "Ground",
+	"cobaberry":   "Flying",
+	"payapaberry": "Psychic",
+	"tangaberry":  "Bug",
+	"chartiberry": "Rock",
+	"kasibberry":  "Ghost",
+	"habanberry":  "Dragon",
+	"colburberry": "Dark",
+	"babiriberry": "Steel",
+	"roseliberry": "Fairy",
+	"chilanberry": "Normal",
+}
+
+// GetResistBerryType returns the type this berry resists, or empty string if none
+func (i *Item) GetResistBerryType() string {
+	if t, ok := TypeResistBerries[i.ID]; ok {
+		return t
+	}
+	return ""
+}
@@ -35,7 +35,7 @@ func ParseLearnset(raw *Learnset, generation int) *ParsedLearnset {
 		Event:   []string{},
 	}
 
-	genStr := string('0' + generation)
+	genStr := string(rune('0' + generation))
 
 	// Track level-up moves with their generation to pick highest gen's level
 	levelUpByMove := make(map[string]struct {
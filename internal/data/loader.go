@@ -18,6 +18,12 @@ type Store struct {
 	Natures   map[string]*Nature
 	Learnsets map[string]*Learnset
 
+	// Wild encounter tables keyed by game ID (emerald, plus hack overlays)
+	Encounters map[string]*EncounterTable
+
+	// Trainer battles keyed by trainer ID
+	Trainers map[string]*Trainer
+
 	// Index maps for case-insensitive lookups
 	pokedexIndex   map[string]string
 	movesIndex     map[string]string
@@ -36,6 +42,8 @@ func NewStore() *Store {
 		TypeChart:      make(map[string]*TypeData),
 		Natures:        make(map[string]*Nature),
 		Learnsets:      make(map[string]*Learnset),
+		Encounters:     make(map[string]*EncounterTable),
+		Trainers:       make(map[string]*Trainer),
 		pokedexIndex:   make(map[string]string),
 		movesIndex:     make(map[string]string),
 		itemsIndex:     make(map[string]string),
@@ -86,6 +94,19 @@ func LoadFromDirectory(dir string) (*Store, error) {
 	// Load catch rates (optional, doesn't fail if missing)
 	_ = store.loadCatchRates(filepath.Join(dir, "catchrates.json"))
 
+	// Load growth rates (optional, doesn't fail if missing)
+	_ = store.loadGrowthRates(filepath.Join(dir, "growthrates.json"))
+
+	// Load wild encounter tables (optional, the directory may be missing)
+	if err := store.loadEncounters(filepath.Join(dir, "encounters")); err != nil {
+		return nil, fmt.Errorf("loading encounters: %w", err)
+	}
+
+	// Load trainer parties (optional, the directory may be missing)
+	if err := store.loadTrainers(filepath.Join(dir, "trainers")); err != nil {
+		return nil, fmt.Errorf("loading trainers: %w", err)
+	}
+
 	return store, nil
 }
 
@@ -242,6 +263,43 @@ func (s *Store) loadCatchRates(path string) error {
 	return nil
 }
 
+// loadGrowthRates applies experience curves by dex number
+// Species missing from the file inherit their pre-evolution's curve
+func (s *Store) loadGrowthRates(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var growthRates map[string]string
+	if err := json.Unmarshal(data, &growthRates); err != nil {
+		return err
+	}
+
+	for _, pokemon := range s.Pokedex {
+		numStr := fmt.Sprintf("%d", pokemon.Num)
+		if rate, ok := growthRates[numStr]; ok {
+			pokemon.GrowthRate = rate
+		}
+	}
+	for _, pokemon := range s.Pokedex {
+		if pokemon.GrowthRate != "" {
+			continue
+		}
+		// Walk up the evolution chain; the depth limit guards against bad data
+		prevo := s.GetPokemon(pokemon.Prevo)
+		for depth := 0; prevo != nil && depth < 3; depth++ {
+			if prevo.GrowthRate != "" {
+				pokemon.GrowthRate = prevo.GrowthRate
+				break
+			}
+			prevo = s.GetPokemon(prevo.Prevo)
+		}
+	}
+
+	return nil
+}
+
 // GetPokemon returns a Pokemon by name or ID (case-insensitive)
 func (s *Store) GetPokemon(nameOrID string) *Pokemon {
 	id := toID(nameOrID)
@@ -251,6 +309,35 @@ func (s *Store) GetPokemon(nameOrID string) *Pokemon {
 	return nil
 }
 
+// GetEvolutionRoot returns the first stage of a Pokemon's evolution line
+// Forms are resolved to their base species first
+func (s *Store) GetEvolutionRoot(nameOrID string) *Pokemon {
+	pokemon := s.GetPokemon(nameOrID)
+	if pokemon == nil {
+		return nil
+	}
+	if pokemon.BaseSpecies != "" {
+		if base := s.GetPokemon(pokemon.BaseSpecies); base != nil {
+			pokemon = base
+		}
+	}
+	for pokemon.Prevo != "" {
+		prevo := s.GetPokemon(pokemon.Prevo)
+		if prevo == nil {
+			break
+		}
+		pokemon = prevo
+	}
+	return pokemon
+}
+
+// SameEvolutionFamily returns true if both Pokemon belong to the same evolution line
+func (s *Store) SameEvolutionFamily(a, b string) bool {
+	rootA := s.GetEvolutionRoot(a)
+	rootB := s.GetEvolutionRoot(b)
+	return rootA != nil && rootA == rootB
+}
+
 // GetMove returns a Move by name or ID (case-insensitive)
 func (s *Store) GetMove(nameOrID string) *Move {
 	id := toID(nameOrID)
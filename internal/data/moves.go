@@ -17,6 +17,12 @@ type Move struct {
 	CritRatio   int              `json:"critRatio,omitempty"`
 	Drain       []int            `json:"drain,omitempty"`       // [numerator, denominator]
 	Recoil      []int            `json:"recoil,omitempty"`      // [numerator, denominator]
+	StruggleRecoil  bool         `json:"struggleRecoil,omitempty"`  // Struggle: user loses 1/4 max HP
+	MindBlownRecoil bool         `json:"mindBlownRecoil,omitempty"` // Mind Blown/Steel Beam: user loses 1/2 max HP
+	HasCrashDamage  bool         `json:"hasCrashDamage,omitempty"`  // Jump Kick/High Jump Kick: user is hurt on miss
+	Damage      interface{}      `json:"damage,omitempty"`      // Fixed damage: int or "level"
+	OHKO        interface{}      `json:"ohko,omitempty"`        // true, or the type that is immune (Sheer Cold: "Ice")
+	SelfDestruct string          `json:"selfdestruct,omitempty"` // "always" or "ifHit": user faints after using the move
 	Multihit    interface{}      `json:"multihit,omitempty"`    // Can be int or [min, max]
 	IgnoreAbility bool           `json:"ignoreAbility,omitempty"`
 	IgnoreDefensive bool         `json:"ignoreDefensive,omitempty"`
@@ -122,3 +128,38 @@ func (m *Move) GetRecoil() (int, int, bool) {
 	}
 	return 0, 0, false
 }
+
+// GetFixedDamage returns the fixed damage dealt by the move
+// Returns (damage, usesLevel, ok); usesLevel is true for moves like Seismic Toss
+func (m *Move) GetFixedDamage() (int, bool, bool) {
+	switch v := m.Damage.(type) {
+	case float64:
+		return int(v), false, true
+	case int:
+		return v, false, true
+	case string:
+		if v == "level" {
+			return 0, true, true
+		}
+	}
+	return 0, false, false
+}
+
+// IsOHKO returns true if the move is a one-hit KO move (Fissure, Sheer Cold, etc.)
+func (m *Move) IsOHKO() bool {
+	switch v := m.OHKO.(type) {
+	case bool:
+		return v
+	case string:
+		return v != ""
+	}
+	return false
+}
+
+// GetOHKOImmuneType returns the type that is immune to this OHKO move, or empty string if none
+func (m *Move) GetOHKOImmuneType() string {
+	if t, ok := m.OHKO.(string); ok {
+		return t
+	}
+	return ""
+}
@@ -19,6 +19,7 @@ type Pokemon struct {
 	Forme       string            `json:"forme,omitempty"`
 	Gender      string            `json:"gender,omitempty"`
 	CatchRate   int               `json:"catchRate,omitempty"`
+	GrowthRate  string            `json:"growthRate,omitempty"` // Experience curve, e.g. "Medium Slow"
 }
 
 // GenderRatio represents the gender distribution of a Pokemon
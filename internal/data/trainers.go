@@ -0,0 +1,154 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// TrainerPokemon is a Pokemon in a trainer's party
+// Moves are omitted when the Pokemon uses its level-up learnset
+type TrainerPokemon struct {
+	Species string   `json:"species"`
+	Level   int      `json:"level"`
+	Moves   []string `json:"moves,omitempty"`
+	Item    string   `json:"item,omitempty"`
+	Ability string   `json:"ability,omitempty"`
+	Nature  string   `json:"nature,omitempty"`
+	IV      *int     `json:"iv,omitempty"` // Gen 3 trainers use one IV for every stat
+}
+
+// Trainer is a trainer battle and its party
+type Trainer struct {
+	ID         string           `json:"id"`
+	Game       string           `json:"game"`
+	Name       string           `json:"name"`
+	Class      string           `json:"class"` // Leader, Elite Four, Champion, Rival, Magma Admin, etc.
+	Location   string           `json:"location"`
+	Badges     int              `json:"badges"`            // Badges the player has when this battle happens
+	BattleType string           `json:"battleType"`        // single or double
+	Variant    string           `json:"variant,omitempty"` // e.g. "player chose Treecko"
+	Notes      string           `json:"notes,omitempty"`
+	Flags      []int            `json:"flags,omitempty"` // In-game trainer IDs; beating any of them sets its defeated flag
+	Party      []TrainerPokemon `json:"party"`
+
+	order int // Position in the data file
+}
+
+// TrainerFilter narrows down a trainer list
+// Negative badge bounds are ignored
+type TrainerFilter struct {
+	Game      string
+	Class     string
+	MinBadges int
+	MaxBadges int
+}
+
+// trainerFile is the on-disk format of a game's trainer list
+type trainerFile struct {
+	Game     string     `json:"game"`
+	Name     string     `json:"name"`
+	Trainers []*Trainer `json:"trainers"`
+}
+
+// loadTrainers loads every game's trainer list from a directory
+func (s *Store) loadTrainers(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range files {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var file trainerFile
+		if err := json.Unmarshal(raw, &file); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		for _, trainer := range file.Trainers {
+			if trainer.Game == "" {
+				trainer.Game = file.Game
+			}
+			trainer.Game = toID(trainer.Game)
+			if trainer.BattleType == "" {
+				trainer.BattleType = "single"
+			}
+			if _, exists := s.Trainers[trainer.ID]; exists {
+				return fmt.Errorf("%s: duplicate trainer ID %s", filepath.Base(path), trainer.ID)
+			}
+			trainer.order = len(s.Trainers)
+			s.Trainers[trainer.ID] = trainer
+		}
+	}
+	return nil
+}
+
+// GetTrainer returns a trainer by ID
+func (s *Store) GetTrainer(id string) *Trainer {
+	return s.Trainers[id]
+}
+
+// DefeatedTrainers returns a game's trainers with a defeated flag in flags, in data file order
+func (s *Store) DefeatedTrainers(game string, flags []int) []*Trainer {
+	set := make(map[int]bool, len(flags))
+	for _, flag := range flags {
+		set[flag] = true
+	}
+
+	game = toID(game)
+	trainers := []*Trainer{}
+	for _, trainer := range s.Trainers {
+		if trainer.Game != game {
+			continue
+		}
+		for _, flag := range trainer.Flags {
+			if set[flag] {
+				trainers = append(trainers, trainer)
+				break
+			}
+		}
+	}
+
+	sort.Slice(trainers, func(i, j int) bool {
+		return trainers[i].order < trainers[j].order
+	})
+	return trainers
+}
+
+// ListTrainers returns the trainers matching a filter, sorted by badge count
+func (s *Store) ListTrainers(filter TrainerFilter) []*Trainer {
+	game := toID(filter.Game)
+	class := toID(filter.Class)
+
+	trainers := []*Trainer{}
+	for _, trainer := range s.Trainers {
+		if game != "" && trainer.Game != game {
+			continue
+		}
+		if class != "" && toID(trainer.Class) != class {
+			continue
+		}
+		if filter.MinBadges >= 0 && trainer.Badges < filter.MinBadges {
+			continue
+		}
+		if filter.MaxBadges >= 0 && trainer.Badges > filter.MaxBadges {
+			continue
+		}
+		trainers = append(trainers, trainer)
+	}
+
+	sort.Slice(trainers, func(i, j int) bool {
+		if trainers[i].Game != trainers[j].Game {
+			return trainers[i].Game < trainers[j].Game
+		}
+		if trainers[i].Badges != trainers[j].Badges {
+			return trainers[i].Badges < trainers[j].Badges
+		}
+		return trainers[i].order < trainers[j].order
+	})
+	return trainers
+}
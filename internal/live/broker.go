@@ -0,0 +1,63 @@
+package live
+
+import "sync"
+
+// clientBuffer is how many events a slow client can fall behind before events are dropped
+const clientBuffer = 4
+
+// Event is a message pushed to every connected client
+type Event struct {
+	Name string // SSE event name, e.g. "save"
+	ID   string
+	Data []byte // JSON payload
+}
+
+// Broker fans events out to subscribers and remembers the latest event
+// so clients that connect later start with the current state
+type Broker struct {
+	mu      sync.Mutex
+	clients map[chan Event]struct{}
+	last    *Event
+}
+
+// NewBroker creates an empty Broker
+func NewBroker() *Broker {
+	return &Broker{clients: make(map[chan Event]struct{})}
+}
+
+// Subscribe registers a client and returns its event channel and a function to unsubscribe
+// The latest event, if any, is delivered first
+func (b *Broker) Subscribe() (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan Event, clientBuffer)
+	if b.last != nil {
+		ch <- *b.last
+	}
+	b.clients[ch] = struct{}{}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.clients[ch]; ok {
+			delete(b.clients, ch)
+			close(ch)
+		}
+	}
+}
+
+// Publish sends an event to every subscriber without blocking
+// Clients whose buffer is full miss the event; they'll catch up on the next one
+func (b *Broker) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.last = &event
+	for ch := range b.clients {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package live
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"os"
+	"time"
+
+	"nuzlocke/internal/savefile"
+)
+
+// Default polling settings
+const (
+	DefaultInterval = 500 * time.Millisecond
+	DefaultDebounce = time.Second
+)
+
+// Snapshot is a complete, checksum-valid save file read from disk
+type Snapshot struct {
+	Path     string
+	Data     []byte
+	Checksum string // SHA-256 of the file contents
+	ModTime  time.Time
+}
+
+// Watcher polls a save file and reports each new version once it has finished being written
+type Watcher struct {
+	Path     string
+	Interval time.Duration // How often the file is checked
+	Debounce time.Duration // How long the file must be unchanged before it's read
+	OnSave   func(Snapshot)
+
+	// State of the file on disk
+	size    int64
+	modTime time.Time
+	changed time.Time // When the size or modification time last changed
+
+	// Last file contents handled, so rewrites with the same data are ignored
+	lastChecksum string
+}
+
+// NewWatcher creates a Watcher with the default interval and debounce
+func NewWatcher(path string, onSave func(Snapshot)) *Watcher {
+	return &Watcher{
+		Path:     path,
+		Interval: DefaultInterval,
+		Debounce: DefaultDebounce,
+		OnSave:   onSave,
+	}
+}
+
+// Run polls the file until the context is cancelled
+// The current file is reported once it has been unchanged for the debounce period
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	w.check(time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.check(now)
+		}
+	}
+}
+
+// check stats the file and reads it once it has been stable for the debounce period
+func (w *Watcher) check(now time.Time) {
+	info, err := os.Stat(w.Path)
+	if err != nil {
+		return // Missing or mid-replace; try again next tick
+	}
+
+	if info.Size() != w.size || !info.ModTime().Equal(w.modTime) {
+		w.size = info.Size()
+		w.modTime = info.ModTime()
+		w.changed = now
+		return
+	}
+	if w.changed.IsZero() || now.Sub(w.changed) < w.Debounce {
+		return
+	}
+	w.changed = time.Time{} // Handle each change once
+
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		log.Printf("Watcher: failed to read %s: %v", w.Path, err)
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	if checksum == w.lastChecksum {
+		return
+	}
+
+	// A partial write leaves sections with bad checksums; wait for the next change
+	if err := savefile.ValidateSave(data); err != nil {
+		log.Printf("Watcher: skipping incomplete save %s: %v", w.Path, err)
+		return
+	}
+
+	w.lastChecksum = checksum
+	w.OnSave(Snapshot{
+		Path:     w.Path,
+		Data:     data,
+		Checksum: checksum,
+		ModTime:  info.ModTime(),
+	})
+}
@@ -127,3 +127,13 @@ func (f *Field) HasScreen(category string) bool {
 	}
 	return false
 }
+
+// AutoFieldResult reports field conditions and stat changes derived from switch-in abilities
+type AutoFieldResult struct {
+	Weather       string   `json:"weather,omitempty"`
+	WeatherSource string   `json:"weatherSource,omitempty"` // e.g. "Pelipper's Drizzle"
+	Terrain       string   `json:"terrain,omitempty"`
+	TerrainSource string   `json:"terrainSource,omitempty"` // e.g. "Tapu Koko's Electric Surge"
+	Boosts        []string `json:"boosts,omitempty"`        // e.g. "Gyarados's Intimidate: Garchomp -1 Atk"
+	Notes         []string `json:"notes,omitempty"`         // Conflicts and speed order details
+}
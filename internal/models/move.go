@@ -32,6 +32,14 @@ func (bm *BattleMove) Initialize(store *data.Store) {
 	bm.MoveData = store.GetMove(bm.Name)
 }
 
+// GetID returns the move's ID (lowercase, no spaces)
+func (bm *BattleMove) GetID() string {
+	if bm.MoveData != nil {
+		return data.ToID(bm.MoveData.Name)
+	}
+	return data.ToID(bm.Name)
+}
+
 // GetBasePower returns the base power (override or from data)
 func (bm *BattleMove) GetBasePower() int {
 	if bm.BasePower > 0 {
@@ -221,3 +221,21 @@ func (bp *BattlePokemon) GetWeight() float64 {
 
 	return weight
 }
+
+// PartyMember is a lightweight party entry used by moves that depend on the
+// rest of the user's party (Beat Up). Field names match the parsed save party
+// so entries from /api/party/parse can be passed through directly
+type PartyMember struct {
+	Species   string `json:"species"`
+	Level     int    `json:"level"`
+	CurrentHP int    `json:"currentHp"` // 0 = fainted
+	Status    string `json:"status,omitempty"`
+
+	SpeciesData *data.Pokemon `json:"-"`
+}
+
+// CanBeatUp returns true if the party member can join a Beat Up attack
+// (not fainted and no non-volatile status condition)
+func (pm *PartyMember) CanBeatUp() bool {
+	return pm.CurrentHP > 0 && pm.Status == ""
+}
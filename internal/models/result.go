@@ -29,8 +29,12 @@ type DamageResult struct {
 
 	// Recoil and recovery
 	Recoil   *RecoilResult   `json:"recoil,omitempty"`
+	Crash    *RecoilResult   `json:"crash,omitempty"` // Crash damage if the move misses (Jump Kick, High Jump Kick)
 	Recovery *RecoveryResult `json:"recovery,omitempty"`
 
+	// Field conditions set by switch-in abilities (only when auto field is requested)
+	AutoField *AutoFieldResult `json:"autoField,omitempty"`
+
 	// Description
 	Description string `json:"description"`
 
@@ -44,12 +48,19 @@ type KOChance struct {
 	N          int     `json:"n"`          // Number of hits needed (1 = OHKO, 2 = 2HKO, etc.)
 	Guaranteed bool    `json:"guaranteed"` // True if 100% chance
 	Text       string  `json:"text"`       // Human-readable description
+
+	Survival []string `json:"survival,omitempty"` // Survival mechanics that came into play (Focus Sash, Sitrus Berry, etc.)
 }
 
-// RecoilResult represents recoil damage
+// RecoilResult represents self-inflicted damage to the attacker across all damage rolls
 type RecoilResult struct {
-	Damage  int     `json:"damage"`
-	Percent float64 `json:"percent"`
+	MinDamage   int      `json:"minDamage"`
+	MaxDamage   int      `json:"maxDamage"`
+	MinPercent  float64  `json:"minPercent"`
+	MaxPercent  float64  `json:"maxPercent"`
+	Sources     []string `json:"sources,omitempty"` // What caused the damage (move recoil, Life Orb, etc.)
+	FaintChance float64  `json:"faintChance"`       // Fraction of rolls where the attacker faints (0.0 to 1.0)
+	Faints      bool     `json:"faints"`            // True if the attacker faints on every roll
 }
 
 // RecoveryResult represents HP recovery
@@ -176,20 +187,43 @@ func (r *DamageResult) CalculateKO(defenderHP, defenderMaxHP int) {
 	}
 }
 
-// CalculateRecoil calculates recoil damage
-func (r *DamageResult) CalculateRecoil(attackerMaxHP, recoilNum, recoilDenom int) {
-	if recoilNum == 0 || recoilDenom == 0 {
-		return
+// NewRecoilResult builds a RecoilResult from per-roll self-damage values
+// Returns nil if no roll causes any damage
+func NewRecoilResult(rolls []int, attackerHP, attackerMaxHP int, sources []string) *RecoilResult {
+	if len(rolls) == 0 {
+		return nil
 	}
 
-	// Recoil is based on damage dealt
-	avgDamage := (r.MinDamage + r.MaxDamage) / 2
-	recoilDamage := avgDamage * recoilNum / recoilDenom
+	minDamage, maxDamage := rolls[0], rolls[0]
+	fainting := 0
+	for _, dmg := range rolls {
+		if dmg < minDamage {
+			minDamage = dmg
+		}
+		if dmg > maxDamage {
+			maxDamage = dmg
+		}
+		if dmg > 0 && dmg >= attackerHP {
+			fainting++
+		}
+	}
+	if maxDamage == 0 {
+		return nil
+	}
 
-	r.Recoil = &RecoilResult{
-		Damage:  recoilDamage,
-		Percent: float64(recoilDamage) / float64(attackerMaxHP) * 100,
+	result := &RecoilResult{
+		MinDamage:   minDamage,
+		MaxDamage:   maxDamage,
+		Sources:     sources,
+		FaintChance: float64(fainting) / float64(len(rolls)),
+		Faints:      fainting == len(rolls),
+	}
+	if attackerMaxHP > 0 {
+		result.MinPercent = float64(minDamage) / float64(attackerMaxHP) * 100
+		result.MaxPercent = float64(maxDamage) / float64(attackerMaxHP) * 100
 	}
+
+	return result
 }
 
 // CalculateRecovery calculates HP recovery from drain moves
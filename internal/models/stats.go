@@ -62,6 +62,36 @@ func (b StatBoosts) GetBoost(stat string) int {
 	}
 }
 
+// AddBoost changes a stat's boost stage, clamped to -6..+6
+// Returns the number of stages actually applied
+func (b *StatBoosts) AddBoost(stat string, delta int) int {
+	var target *int
+	switch stat {
+	case "atk":
+		target = &b.Atk
+	case "def":
+		target = &b.Def
+	case "spa":
+		target = &b.SpA
+	case "spd":
+		target = &b.SpD
+	case "spe":
+		target = &b.Spe
+	default:
+		return 0
+	}
+
+	before := *target
+	*target += delta
+	if *target > 6 {
+		*target = 6
+	}
+	if *target < -6 {
+		*target = -6
+	}
+	return *target - before
+}
+
 // floorDiv performs integer floor division
 func floorDiv(a, b int) int {
 	if b == 0 {
@@ -0,0 +1,210 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SurvivalMechanics describes defender effects that change how many hits it takes to KO
+// (Focus Sash, Sturdy, Disguise, resist berries, Sitrus Berry, Multiscale breaking)
+type SurvivalMechanics struct {
+	MaxHP      int
+	CurrentHP  int
+	HitsPerUse int // Strikes per use of the move (multi-hit moves break Focus Sash and Sturdy)
+
+	// Damage rolls once the defender is below full HP (Multiscale / Shadow Shield broken)
+	// nil means the rolls don't change
+	DamagedRolls []int
+
+	FocusSash bool // Survive a hit from full HP at 1 HP (consumed)
+	Sturdy    bool // Gen 5+: survive a hit from full HP at 1 HP
+	FocusBand bool // 10% chance to survive any hit at 1 HP
+
+	Disguise     bool // First hit deals no damage (Disguise, Ice Face)
+	DisguiseName string
+	DisguiseChip int // HP lost when the disguise breaks (Gen 8+: 1/8 max HP)
+
+	ResistBerry string // Halves the first hit (consumed)
+
+	HealBerry     string // Restores HP at 1/2 max HP or less (consumed)
+	HealAmount    int
+	HealThreshold int // Berry activates at or below this HP
+}
+
+// survivalState is one possible defender state during the KO simulation
+type survivalState struct {
+	hp           int
+	itemUsed     bool
+	disguiseUsed bool
+}
+
+// CalculateKOWithSurvival calculates KO probability by simulating every damage roll
+// hit by hit, applying survival items and abilities between hits
+func (r *DamageResult) CalculateKOWithSurvival(m *SurvivalMechanics) {
+	if len(r.Damages) == 0 || m.CurrentHP <= 0 {
+		return
+	}
+
+	hitsPerUse := m.HitsPerUse
+	if hitsPerUse < 1 {
+		hitsPerUse = 1
+	}
+
+	states := map[survivalState]float64{{hp: m.CurrentHP}: 1.0}
+	koChance := 0.0
+	triggered := make(map[string]bool)
+
+	for n := 1; n <= 4; n++ {
+		for hit := 0; hit < hitsPerUse; hit++ {
+			next := make(map[survivalState]float64)
+			for state, prob := range states {
+				rolls := r.Damages
+				if state.hp < m.MaxHP && m.DamagedRolls != nil {
+					rolls = m.DamagedRolls
+				}
+				rollProb := prob / float64(len(rolls))
+				for _, dmg := range rolls {
+					for _, outcome := range m.applyHit(state, dmg, triggered) {
+						if outcome.state.hp <= 0 {
+							koChance += rollProb * outcome.chance
+						} else {
+							next[outcome.state] += rollProb * outcome.chance
+						}
+					}
+				}
+			}
+			states = next
+		}
+
+		if koChance > 0 {
+			r.KOChance = newSurvivalKOChance(koChance, n, triggered, r.MaxDamage >= m.CurrentHP)
+			return
+		}
+	}
+
+	r.KOChance = newSurvivalKOChance(0, 0, triggered, false)
+}
+
+// hitOutcome is a possible result of a single hit and its probability
+type hitOutcome struct {
+	state  survivalState
+	chance float64
+}
+
+// applyHit applies one hit to a defender state and returns the possible outcomes
+func (m *SurvivalMechanics) applyHit(state survivalState, dmg int, triggered map[string]bool) []hitOutcome {
+	if dmg <= 0 {
+		return []hitOutcome{{state: state, chance: 1}}
+	}
+
+	// Disguise / Ice Face take the first hit
+	if m.Disguise && !state.disguiseUsed {
+		state.disguiseUsed = true
+		state.hp -= m.DisguiseChip
+		triggered[m.DisguiseName] = true
+		return []hitOutcome{{state: state, chance: 1}}
+	}
+
+	// Resist berry halves the first hit
+	if m.ResistBerry != "" && !state.itemUsed {
+		state.itemUsed = true
+		dmg = dmg / 2
+		if dmg < 1 {
+			dmg = 1
+		}
+		triggered[m.ResistBerry] = true
+	}
+
+	startHP := state.hp
+	state.hp -= dmg
+
+	if state.hp <= 0 {
+		// Focus Sash and Sturdy only work from full HP
+		if startHP == m.MaxHP {
+			if m.FocusSash && !state.itemUsed {
+				state.itemUsed = true
+				state.hp = 1
+				triggered["Focus Sash"] = true
+				return []hitOutcome{{state: state, chance: 1}}
+			}
+			if m.Sturdy {
+				state.hp = 1
+				triggered["Sturdy"] = true
+				return []hitOutcome{{state: state, chance: 1}}
+			}
+		}
+		if m.FocusBand {
+			triggered["Focus Band"] = true
+			survived := state
+			survived.hp = 1
+			return []hitOutcome{{state: survived, chance: 0.1}, {state: state, chance: 0.9}}
+		}
+		return []hitOutcome{{state: state, chance: 1}}
+	}
+
+	// Sitrus / Oran Berry activate after the hit
+	if m.HealBerry != "" && !state.itemUsed && state.hp <= m.HealThreshold {
+		state.itemUsed = true
+		state.hp += m.HealAmount
+		if state.hp > m.MaxHP {
+			state.hp = m.MaxHP
+		}
+		triggered[m.HealBerry] = true
+	}
+
+	return []hitOutcome{{state: state, chance: 1}}
+}
+
+// newSurvivalKOChance builds the KO chance text, noting which survival mechanics came into play
+func newSurvivalKOChance(chance float64, n int, triggered map[string]bool, rawOHKO bool) *KOChance {
+	var notes []string
+	for name := range triggered {
+		notes = append(notes, name)
+	}
+	sort.Strings(notes)
+
+	ko := &KOChance{
+		Chance:     chance,
+		N:          n,
+		Guaranteed: chance >= 0.9999,
+		Survival:   notes,
+	}
+	if ko.Guaranteed {
+		ko.Chance = 1.0
+	}
+
+	hitName := "OHKO"
+	if n > 1 {
+		hitName = fmt.Sprintf("%dHKO", n)
+	}
+
+	var text string
+	switch {
+	case n == 0:
+		text = "not a KO"
+	case ko.Guaranteed:
+		text = "guaranteed " + hitName
+	default:
+		text = fmt.Sprintf("%.1f%% chance to %s", chance*100, hitName)
+	}
+
+	// A hit that would have been an OHKO on raw HP, but the defender survived at 1 HP
+	var survivedBy, others []string
+	for _, name := range notes {
+		if rawOHKO && n != 1 && (name == "Focus Sash" || name == "Sturdy" || name == "Focus Band") {
+			survivedBy = append(survivedBy, name)
+		} else {
+			others = append(others, name)
+		}
+	}
+	if len(survivedBy) > 0 {
+		text = fmt.Sprintf("survives at 1 HP (%s), %s", strings.Join(survivedBy, ", "), text)
+	}
+	if len(others) > 0 {
+		text += " (" + strings.Join(others, ", ") + ")"
+	}
+
+	ko.Text = text
+	return ko
+}
@@ -0,0 +1,434 @@
+package planner
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"nuzlocke/internal/calc"
+	"nuzlocke/internal/data"
+	"nuzlocke/internal/models"
+)
+
+// maxRecommendations is the number of leads and switch-ins suggested
+const maxRecommendations = 5
+
+// Combatant is a Pokemon on either side of a boss fight with the moves it can use
+type Combatant struct {
+	Pokemon     *models.BattlePokemon
+	Moves       []string
+	Nickname    string
+	Source      string // party, box or trainer
+	Slot        int    // Position in the party, box (box*30+slot) or trainer party
+	Personality uint32
+}
+
+// MoveResult is the damage one move does in a matchup
+type MoveResult struct {
+	Move       string  `json:"move"`
+	MinPercent float64 `json:"minPercent"`
+	MaxPercent float64 `json:"maxPercent"`
+	KO         string  `json:"ko,omitempty"` // e.g. "guaranteed 2HKO"
+	Hits       int     `json:"hits,omitempty"`
+}
+
+// SpeedComparison compares the player's and the enemy's in-battle speed
+type SpeedComparison struct {
+	Speed      int    `json:"speed"`
+	EnemySpeed int    `json:"enemySpeed"`
+	Faster     string `json:"faster"` // player, enemy or tie
+}
+
+// Matchup is how one of the player's Pokemon fares against one enemy Pokemon
+type Matchup struct {
+	Enemy         string          `json:"enemy"`
+	EnemyIndex    int             `json:"enemyIndex"`
+	EnemyLevel    int             `json:"enemyLevel"`
+	BestMove      *MoveResult     `json:"bestMove,omitempty"`      // Highest damage move against the enemy
+	WorstIncoming *MoveResult     `json:"worstIncoming,omitempty"` // Enemy's highest damage move against us
+	Speed         SpeedComparison `json:"speed"`
+	Score         float64         `json:"score"` // Higher is better
+}
+
+// MemberPlan is every matchup for one of the player's Pokemon
+type MemberPlan struct {
+	Species     string    `json:"species"`
+	Nickname    string    `json:"nickname,omitempty"`
+	Level       int       `json:"level"`
+	Source      string    `json:"source"`
+	Slot        int       `json:"slot"`
+	Personality uint32    `json:"personality"`
+	Matchups    []Matchup `json:"matchups"`
+}
+
+// Recommendation is a ranked pick for a lead or a switch-in
+type Recommendation struct {
+	Species     string  `json:"species"`
+	Nickname    string  `json:"nickname,omitempty"`
+	Source      string  `json:"source"`
+	Personality uint32  `json:"personality"`
+	Score       float64 `json:"score"`
+	Reason      string  `json:"reason"`
+}
+
+// SwitchIns ranks the safest switch-ins against one enemy Pokemon
+type SwitchIns struct {
+	Enemy      string           `json:"enemy"`
+	EnemyIndex int              `json:"enemyIndex"`
+	Options    []Recommendation `json:"options"`
+}
+
+// Plan is the full boss fight plan
+type Plan struct {
+	Trainer     *data.Trainer    `json:"trainer"`
+	Assumptions []string         `json:"assumptions"`
+	Members     []MemberPlan     `json:"members"`
+	Leads       []Recommendation `json:"leads"`
+	SwitchIns   []SwitchIns      `json:"switchIns"`
+}
+
+// Planner runs damage calculations for every matchup in a boss fight
+type Planner struct {
+	Store      *data.Store
+	Calculator *calc.Calculator
+}
+
+// New creates a Planner
+func New(store *data.Store, calculator *calc.Calculator) *Planner {
+	return &Planner{Store: store, Calculator: calculator}
+}
+
+// PlanBoss builds a plan for the player's Pokemon against a trainer's party
+// using the battle mechanics of the given generation
+func (p *Planner) PlanBoss(trainer *data.Trainer, team []*Combatant, generation int) *Plan {
+	enemies, assumptions := p.TrainerTeam(trainer, generation)
+	assumptions = append(assumptions,
+		"Party Pokemon keep their HP and status from the save and fainted ones are left out; boxed Pokemon are at full HP",
+		"Switch-in abilities (Intimidate, Drizzle, etc.) activate as if both Pokemon just switched in",
+		"Damage excludes critical hits",
+	)
+	if trainer.BattleType == "double" {
+		assumptions = append(assumptions, "Double battle: matchups are calculated one-on-one without spread damage")
+	}
+
+	plan := &Plan{
+		Trainer:     trainer,
+		Assumptions: assumptions,
+		Members:     make([]MemberPlan, 0, len(team)),
+		Leads:       []Recommendation{},
+		SwitchIns:   make([]SwitchIns, 0, len(enemies)),
+	}
+
+	for _, member := range team {
+		mp := MemberPlan{
+			Species:     member.Pokemon.Species,
+			Nickname:    member.Nickname,
+			Level:       member.Pokemon.Level,
+			Source:      member.Source,
+			Slot:        member.Slot,
+			Personality: member.Personality,
+			Matchups:    make([]Matchup, 0, len(enemies)),
+		}
+		for i, enemy := range enemies {
+			mp.Matchups = append(mp.Matchups, p.matchup(member, enemy, i, generation))
+		}
+		plan.Members = append(plan.Members, mp)
+	}
+
+	if len(enemies) == 0 {
+		return plan
+	}
+
+	// Leads face the trainer's first Pokemon; ties go to the better average against the rest
+	leads := make([]Recommendation, 0, len(plan.Members))
+	for i, mp := range plan.Members {
+		m := mp.Matchups[0]
+		leads = append(leads, recommend(team[i], round(m.Score+averageScore(mp.Matchups)/10), leadReason(m)))
+	}
+	plan.Leads = top(leads)
+
+	// Switch-ins need to take the enemy's hits, so rank by the least damage taken
+	for e, enemy := range enemies {
+		options := make([]Recommendation, 0, len(plan.Members))
+		for i, mp := range plan.Members {
+			m := mp.Matchups[e]
+			taken := 0.0
+			if m.WorstIncoming != nil {
+				taken = math.Min(m.WorstIncoming.MaxPercent, 100) / 100
+			}
+			options = append(options, recommend(team[i], round(1-taken+m.Score/10), switchReason(m)))
+		}
+		plan.SwitchIns = append(plan.SwitchIns, SwitchIns{
+			Enemy:      enemy.Pokemon.Species,
+			EnemyIndex: e,
+			Options:    top(options),
+		})
+	}
+
+	return plan
+}
+
+// TrainerTeam builds the trainer's party, filling in unknown IVs and movesets
+// Returns the assumptions made along the way
+func (p *Planner) TrainerTeam(trainer *data.Trainer, generation int) ([]*Combatant, []string) {
+	var assumptions []string
+	defaultIVs := false
+	team := make([]*Combatant, 0, len(trainer.Party))
+
+	for i, tp := range trainer.Party {
+		pokemon := models.NewBattlePokemon(tp.Species)
+		pokemon.Level = tp.Level
+		pokemon.Nature = tp.Nature
+		pokemon.Ability = tp.Ability
+		pokemon.Item = tp.Item
+		if tp.IV != nil {
+			iv := *tp.IV
+			pokemon.IVs = models.StatSpread{HP: iv, Atk: iv, Def: iv, SpA: iv, SpD: iv, Spe: iv}
+		} else {
+			defaultIVs = true
+		}
+		if pokemon.Ability == "" {
+			if species := p.Store.GetPokemon(tp.Species); species != nil {
+				pokemon.Ability = species.GetAbility("0")
+			}
+		}
+
+		moves := tp.Moves
+		if len(moves) == 0 {
+			moves = p.levelUpMoves(tp.Species, tp.Level, generation)
+			if len(moves) > 0 {
+				assumptions = append(assumptions, fmt.Sprintf("%s uses its last %d level-up moves", tp.Species, len(moves)))
+			} else {
+				assumptions = append(assumptions, fmt.Sprintf("%s's moves are unknown, so its damage isn't calculated", tp.Species))
+			}
+		}
+
+		team = append(team, &Combatant{Pokemon: pokemon, Moves: moves, Source: "trainer", Slot: i})
+	}
+
+	if defaultIVs {
+		assumptions = append(assumptions, "Enemy IVs are assumed to be 31 (worst case) where the trainer data doesn't list them")
+	}
+	return team, assumptions
+}
+
+// levelUpMoves returns the last four moves a species learns by level up at or below a level,
+// in a generation, which is what trainers without custom movesets use
+func (p *Planner) levelUpMoves(species string, level, generation int) []string {
+	pokemon := p.Store.GetPokemon(species)
+	if pokemon == nil {
+		return nil
+	}
+	learnset := data.ParseLearnset(p.Store.GetLearnset(data.ToID(pokemon.Name)), generation)
+
+	known := make([]data.LevelUpMove, 0, len(learnset.LevelUp))
+	for _, m := range learnset.LevelUp {
+		if m.Level <= level {
+			known = append(known, m)
+		}
+	}
+	sort.SliceStable(known, func(i, j int) bool {
+		if known[i].Level != known[j].Level {
+			return known[i].Level < known[j].Level
+		}
+		return known[i].Move < known[j].Move
+	})
+	if len(known) > 4 {
+		known = known[len(known)-4:]
+	}
+
+	moves := make([]string, 0, len(known))
+	for _, m := range known {
+		if move := p.Store.GetMove(m.Move); move != nil {
+			moves = append(moves, move.Name)
+		}
+	}
+	return moves
+}
+
+// matchup calculates both sides' best moves and compares speed
+func (p *Planner) matchup(member, enemy *Combatant, index, generation int) Matchup {
+	m := Matchup{
+		Enemy:      enemy.Pokemon.Species,
+		EnemyIndex: index,
+		EnemyLevel: enemy.Pokemon.Level,
+	}
+
+	var field *models.Field
+	m.BestMove, field = p.bestMove(member, enemy, generation)
+	m.WorstIncoming, _ = p.bestMove(enemy, member, generation)
+	if field == nil {
+		field = &models.Field{Generation: generation}
+	}
+
+	// Speeds are compared after switch-in abilities set the weather (Swift Swim, Chlorophyll)
+	player := *member.Pokemon
+	opponent := *enemy.Pokemon
+	player.Initialize(p.Store)
+	opponent.Initialize(p.Store)
+	m.Speed = SpeedComparison{
+		Speed:      calc.EffectiveSpeed(&player, models.SideConditions{}, field),
+		EnemySpeed: calc.EffectiveSpeed(&opponent, models.SideConditions{}, field),
+	}
+	switch {
+	case m.Speed.Speed > m.Speed.EnemySpeed:
+		m.Speed.Faster = "player"
+	case m.Speed.Speed < m.Speed.EnemySpeed:
+		m.Speed.Faster = "enemy"
+	default:
+		m.Speed.Faster = "tie"
+	}
+
+	m.Score = score(m)
+	return m
+}
+
+// bestMove returns the attacker's highest damage move against the defender and the field
+// the calculation ran on
+func (p *Planner) bestMove(attacker, defender *Combatant, generation int) (*MoveResult, *models.Field) {
+	var best *MoveResult
+	var field *models.Field
+
+	for _, name := range attacker.Moves {
+		// Copy the Pokemon so Intimidate and other boosts don't carry over between calculations
+		a := *attacker.Pokemon
+		d := *defender.Pokemon
+		req := &calc.CalculateRequest{
+			Attacker:   &a,
+			Defender:   &d,
+			Move:       models.NewBattleMove(name),
+			Field:      &models.Field{Generation: generation},
+			Generation: generation,
+			AutoField:  true,
+		}
+		result := p.Calculator.Calculate(req)
+		if field == nil {
+			field = req.Field
+		}
+		if req.Move.MoveData == nil || req.Move.IsStatus() {
+			continue
+		}
+
+		r := &MoveResult{
+			Move:       req.Move.MoveData.Name,
+			MinPercent: result.MinPercent,
+			MaxPercent: result.MaxPercent,
+		}
+		if result.MaxTotalPct > 0 {
+			r.MinPercent = result.MinTotalPct
+			r.MaxPercent = result.MaxTotalPct
+		}
+		if result.KOChance != nil {
+			r.KO = result.KOChance.Text
+			r.Hits = result.KOChance.N
+		}
+		if best == nil || r.MaxPercent > best.MaxPercent {
+			best = r
+		}
+	}
+	return best, field
+}
+
+// score rates a matchup: damage dealt minus damage taken (each capped at 100%),
+// plus a bonus for moving first
+func score(m Matchup) float64 {
+	s := 0.0
+	if m.BestMove != nil {
+		s += math.Min(m.BestMove.MinPercent, 100) / 100
+	}
+	if m.WorstIncoming != nil {
+		s -= math.Min(m.WorstIncoming.MaxPercent, 100) / 100
+	}
+	switch m.Speed.Faster {
+	case "player":
+		s += 0.25
+	case "enemy":
+		s -= 0.25
+	}
+	return round(s)
+}
+
+// averageScore returns the mean score across matchups
+func averageScore(matchups []Matchup) float64 {
+	if len(matchups) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, m := range matchups {
+		total += m.Score
+	}
+	return total / float64(len(matchups))
+}
+
+// recommend builds a Recommendation for a combatant
+func recommend(c *Combatant, score float64, reason string) Recommendation {
+	return Recommendation{
+		Species:     c.Pokemon.Species,
+		Nickname:    c.Nickname,
+		Source:      c.Source,
+		Personality: c.Personality,
+		Score:       score,
+		Reason:      reason,
+	}
+}
+
+// leadReason summarizes a lead matchup
+func leadReason(m Matchup) string {
+	reason := fmt.Sprintf("%s vs %s", speedText(m.Speed), m.Enemy)
+	if m.BestMove != nil {
+		reason += fmt.Sprintf("; %s does %s", m.BestMove.Move, percentRange(m.BestMove))
+	}
+	if m.WorstIncoming != nil {
+		reason += fmt.Sprintf("; takes up to %.1f%% from %s", m.WorstIncoming.MaxPercent, m.WorstIncoming.Move)
+	}
+	return reason
+}
+
+// switchReason summarizes a switch-in matchup
+func switchReason(m Matchup) string {
+	if m.WorstIncoming == nil {
+		return fmt.Sprintf("%s has no damaging moves", m.Enemy)
+	}
+	reason := fmt.Sprintf("Takes up to %.1f%% from %s", m.WorstIncoming.MaxPercent, m.WorstIncoming.Move)
+	if m.BestMove != nil {
+		reason += fmt.Sprintf("; %s does %s", m.BestMove.Move, percentRange(m.BestMove))
+	}
+	return reason
+}
+
+// speedText describes who moves first
+func speedText(s SpeedComparison) string {
+	switch s.Faster {
+	case "player":
+		return fmt.Sprintf("Outspeeds (%d vs %d)", s.Speed, s.EnemySpeed)
+	case "enemy":
+		return fmt.Sprintf("Slower (%d vs %d)", s.Speed, s.EnemySpeed)
+	default:
+		return fmt.Sprintf("Speed tie (%d)", s.Speed)
+	}
+}
+
+// percentRange formats a move's damage range
+func percentRange(r *MoveResult) string {
+	text := fmt.Sprintf("%.1f-%.1f%%", r.MinPercent, r.MaxPercent)
+	if r.KO != "" {
+		text += " (" + r.KO + ")"
+	}
+	return text
+}
+
+// top sorts recommendations by score and keeps the best few
+func top(recs []Recommendation) []Recommendation {
+	sort.SliceStable(recs, func(i, j int) bool {
+		return recs[i].Score > recs[j].Score
+	})
+	if len(recs) > maxRecommendations {
+		recs = recs[:maxRecommendations]
+	}
+	return recs
+}
+
+// round rounds a score to three decimal places
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,143 @@
+package planner
+
+import (
+	"math"
+
+	"nuzlocke/internal/data"
+	"nuzlocke/internal/models"
+	"nuzlocke/internal/savefile"
+)
+
+// boxSize is the number of slots in a PC box
+const boxSize = 30
+
+// SaveTeam builds combatants from a parsed save's party and boxes
+// Pokemon for which skip returns true (e.g. dead in a run) are left out
+func SaveTeam(store *data.Store, save *savefile.ParseResult, skip func(personality uint32) bool) []*Combatant {
+	var team []*Combatant
+	retro := save.Game.Generation() <= 2
+
+	for i, p := range save.Party {
+		// Fainted Pokemon can't fight until they're revived
+		if p.CurrentHP == 0 || skip != nil && skip(p.Personality) {
+			continue
+		}
+		ivs, evs := p.IVs, p.EVs
+		if retro {
+			ivs, evs = retroSpreads(ivs, evs)
+		}
+		if c := saveCombatant(store, p.SpeciesNum, p.Level, p.Nature, p.AbilitySlot, p.ItemNum, movesWithPP(p), ivs, evs); c != nil {
+			c.Pokemon.CurrentHP = p.CurrentHP
+			c.Pokemon.Status = p.Status.Condition
+			c.Nickname = p.Nickname
+			c.Source = "party"
+			c.Slot = i
+			c.Personality = p.Personality
+			team = append(team, c)
+		}
+	}
+
+	for b, box := range save.Boxes {
+		for i, p := range box {
+			if skip != nil && skip(p.Personality) {
+				continue
+			}
+			ivs, evs := p.IVs, p.EVs
+			if retro {
+				ivs, evs = retroSpreads(ivs, evs)
+			}
+			if c := saveCombatant(store, p.SpeciesNum, p.Level, p.Nature, p.AbilitySlot, p.ItemNum, p.MoveNums, ivs, evs); c != nil {
+				c.Nickname = p.Nickname
+				c.Source = "box"
+				c.Slot = b*boxSize + i
+				c.Personality = p.Personality
+				team = append(team, c)
+			}
+		}
+	}
+
+	return team
+}
+
+// movesWithPP returns a party Pokemon's moves that have PP left, or all of its moves if
+// none do (it would have to Struggle)
+func movesWithPP(p savefile.PartyPokemon) []int {
+	if len(p.PP) != len(p.MoveNums) {
+		return p.MoveNums
+	}
+	moves := make([]int, 0, len(p.MoveNums))
+	for i, num := range p.MoveNums {
+		if p.PP[i] > 0 {
+			moves = append(moves, num)
+		}
+	}
+	if len(moves) == 0 {
+		return p.MoveNums
+	}
+	return moves
+}
+
+// saveCombatant builds a combatant from save data, or nil if the species is unknown
+func saveCombatant(store *data.Store, speciesNum, level int, nature string, abilitySlot, itemNum int, moveNums []int, ivs, evs savefile.PokemonStats) *Combatant {
+	species := store.GetPokemonByNum(speciesNum)
+	if species == nil {
+		return nil
+	}
+
+	pokemon := models.NewBattlePokemon(species.Name)
+	pokemon.Level = level
+	pokemon.Nature = nature
+	pokemon.IVs = statSpread(ivs)
+	pokemon.EVs = statSpread(evs)
+
+	// Gen 3 only has two ability slots; fall back to the first if the second doesn't exist
+	slot := "0"
+	if abilitySlot == 1 {
+		slot = "1"
+	}
+	pokemon.Ability = species.GetAbility(slot)
+	if pokemon.Ability == "" {
+		pokemon.Ability = species.GetAbility("0")
+	}
+
+	if itemNum > 0 {
+		if item := store.GetItemByNum(itemNum); item != nil {
+			pokemon.Item = item.Name
+		}
+	}
+
+	moves := make([]string, 0, len(moveNums))
+	for _, num := range moveNums {
+		if move := store.GetMoveByNum(num); move != nil {
+			moves = append(moves, move.Name)
+		}
+	}
+
+	return &Combatant{Pokemon: pokemon, Moves: moves}
+}
+
+// statSpread converts save file stats to a StatSpread
+func statSpread(s savefile.PokemonStats) models.StatSpread {
+	return models.StatSpread{HP: s.HP, Atk: s.Attack, Def: s.Defense, SpA: s.SpAtk, SpD: s.SpDef, Spe: s.Speed}
+}
+
+// retroSpreads converts Gen 1 and 2 DVs and stat experience, which the save reports as IVs
+// and EVs, to the IVs and EVs that give the same stats under the Gen 3 formula
+// Gen 1 and 2 add twice the DV and a quarter of the rounded-up square root of the stat
+// experience to twice the base stat, where Gen 3 adds the IV and a quarter of the EVs.
+func retroSpreads(dvs, statExp savefile.PokemonStats) (ivs, evs savefile.PokemonStats) {
+	convert := func(dv, exp int) (int, int) {
+		root := int(math.Sqrt(float64(exp)))
+		if root*root < exp {
+			root++
+		}
+		return dv * 2, root / 4 * 4
+	}
+	ivs.HP, evs.HP = convert(dvs.HP, statExp.HP)
+	ivs.Attack, evs.Attack = convert(dvs.Attack, statExp.Attack)
+	ivs.Defense, evs.Defense = convert(dvs.Defense, statExp.Defense)
+	ivs.SpAtk, evs.SpAtk = convert(dvs.SpAtk, statExp.SpAtk)
+	ivs.SpDef, evs.SpDef = convert(dvs.SpDef, statExp.SpDef)
+	ivs.Speed, evs.Speed = convert(dvs.Speed, statExp.Speed)
+	return ivs, evs
+}
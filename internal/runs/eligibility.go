@@ -0,0 +1,195 @@
+package runs
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"nuzlocke/internal/data"
+)
+
+// EligibleSpecies is a species from a location's encounter table and whether it still counts
+type EligibleSpecies struct {
+	Species  string  `json:"species"`
+	MinLevel int     `json:"minLevel"`
+	MaxLevel int     `json:"maxLevel"`
+	Rate     float64 `json:"rate"` // Percent chance per encounter (after the Repel trick, if any)
+	Eligible bool    `json:"eligible"`
+	Reason   string  `json:"reason,omitempty"` // Why the species is a dupe, e.g. "Poochyena family (route116: Mightyena)"
+}
+
+// MethodEligibility is the eligibility of one encounter method at a location
+type MethodEligibility struct {
+	Method         string            `json:"method"`
+	Species        []EligibleSpecies `json:"species"`
+	EligibleChance float64           `json:"eligibleChance"` // Chance the next encounter counts (0-1)
+}
+
+// LocationEligibility reports which encounters at a location still count for a run
+type LocationEligibility struct {
+	Location    string              `json:"location"`
+	Name        string              `json:"name"`
+	Used        bool                `json:"used"` // The location's encounter has already been recorded
+	UsedBy      *Encounter          `json:"usedBy,omitempty"`
+	ShinyClause bool                `json:"shinyClause"` // Shinies can be caught regardless
+	RepelLevel  int                 `json:"repelLevel,omitempty"`
+	Methods     []MethodEligibility `json:"methods"`
+}
+
+// dupeSource records the encounter that claimed a species or family
+type dupeSource struct {
+	species  string
+	location string
+}
+
+// LocationEligibility returns the remaining eligible species at a location, applying the
+// dupes clause (whole evolution families), species clause and exemptions.
+// With repelLevel > 0, slots below that level are blocked by a Repel led by a Pokemon
+// of that level, and the rates are renormalized over the encounters that can still happen.
+func (r *Run) LocationEligibility(store *data.Store, game, location string, repelLevel int) (*LocationEligibility, error) {
+	loc := store.GetLocationEncounters(game, location)
+	if loc == nil {
+		return nil, fmt.Errorf("no encounters for %s in %s", location, game)
+	}
+
+	result := &LocationEligibility{
+		Location:    loc.ID,
+		Name:        loc.Name,
+		ShinyClause: r.Ruleset.ShinyClause,
+		RepelLevel:  repelLevel,
+		Methods:     []MethodEligibility{},
+	}
+	if enc, ok := r.Encounters[loc.ID]; ok {
+		result.Used = true
+		result.UsedBy = enc
+	}
+
+	claimed := r.claimedSpecies(store)
+	exempt := make(map[string]bool)
+	for _, species := range r.Ruleset.Exemptions {
+		exempt[r.dupeKey(store, species)] = true
+	}
+
+	for _, method := range data.EncounterMethods {
+		slots := loc.Slots(method)
+		if len(slots) == 0 {
+			continue
+		}
+
+		// Repel applies per slot, since one species' slots can span very different levels
+		repelled := make(map[string]float64)
+		for _, slot := range slots {
+			repelled[data.ToID(slot.Species)] += float64(slot.Rate) * repelFraction(slot, repelLevel)
+		}
+
+		m := MethodEligibility{Method: method, Species: []EligibleSpecies{}}
+		total := 0.0
+		for _, slot := range data.CombineSlots(slots) {
+			rate := repelled[data.ToID(slot.Species)]
+			if rate == 0 {
+				continue
+			}
+			total += rate
+
+			species := EligibleSpecies{
+				Species:  slot.Species,
+				MinLevel: slot.MinLevel,
+				MaxLevel: slot.MaxLevel,
+				Rate:     rate,
+				Eligible: true,
+			}
+			key := r.dupeKey(store, slot.Species)
+			if src, ok := claimed[key]; ok && !exempt[key] {
+				species.Eligible = false
+				species.Reason = r.dupeReason(store, src)
+			}
+			m.Species = append(m.Species, species)
+		}
+
+		// Renormalize so rates are per encounter that actually happens
+		for i := range m.Species {
+			if total > 0 {
+				m.Species[i].Rate = m.Species[i].Rate * 100 / total
+			}
+			if m.Species[i].Eligible {
+				m.EligibleChance += m.Species[i].Rate / 100
+			}
+		}
+		m.EligibleChance = math.Min(m.EligibleChance, 1) // Float rounding
+		sort.SliceStable(m.Species, func(i, j int) bool {
+			return m.Species[i].Rate > m.Species[j].Rate
+		})
+		result.Methods = append(result.Methods, m)
+	}
+
+	return result, nil
+}
+
+// claimedSpecies returns the dupe keys of every species the run has caught, been gifted or
+// knocked out, mapped to the encounter that claimed it
+func (r *Run) claimedSpecies(store *data.Store) map[string]dupeSource {
+	claimed := make(map[string]dupeSource)
+	if !r.Ruleset.DupesClause && !r.Ruleset.SpeciesClause {
+		return claimed
+	}
+
+	// Sort for a stable "first claimed by" location
+	locations := make([]string, 0, len(r.Encounters))
+	for location := range r.Encounters {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+
+	for _, location := range locations {
+		enc := r.Encounters[location]
+		if !enc.Status.Obtained() && enc.Status != StatusFainted {
+			continue
+		}
+		key := r.dupeKey(store, enc.Species)
+		if _, ok := claimed[key]; !ok {
+			claimed[key] = dupeSource{species: enc.Species, location: location}
+		}
+	}
+	return claimed
+}
+
+// dupeKey returns the key two species share when the rules treat them as duplicates:
+// the evolution family under the dupes clause, the species itself under the species clause
+func (r *Run) dupeKey(store *data.Store, species string) string {
+	if r.Ruleset.DupesClause {
+		if root := store.GetEvolutionRoot(species); root != nil {
+			return data.ToID(root.Name)
+		}
+	}
+	if pokemon := store.GetPokemon(species); pokemon != nil && pokemon.BaseSpecies != "" {
+		return data.ToID(pokemon.BaseSpecies)
+	}
+	return data.ToID(species)
+}
+
+// dupeReason describes the encounter that made a species a dupe
+func (r *Run) dupeReason(store *data.Store, src dupeSource) string {
+	where := src.location
+	if enc, ok := r.Encounters[src.location]; ok && enc.Status == StatusFainted {
+		where += ", fainted"
+	}
+	if r.Ruleset.DupesClause {
+		if root := store.GetEvolutionRoot(src.species); root != nil {
+			return fmt.Sprintf("%s family (%s: %s)", root.Name, where, src.species)
+		}
+	}
+	return fmt.Sprintf("%s (%s)", src.species, where)
+}
+
+// repelFraction returns the fraction of a slot's levels a Repel doesn't block
+// Repel blocks wild Pokemon below the lead Pokemon's level
+func repelFraction(slot data.EncounterSlot, repelLevel int) float64 {
+	if repelLevel <= slot.MinLevel {
+		return 1
+	}
+	if repelLevel > slot.MaxLevel {
+		return 0
+	}
+	levels := slot.MaxLevel - slot.MinLevel + 1
+	return float64(slot.MaxLevel-repelLevel+1) / float64(levels)
+}
@@ -0,0 +1,26 @@
+package runs
+
+import "time"
+
+// Event is a change between two uploaded saves, recorded in the run's log
+type Event struct {
+	Time        time.Time `json:"time"`
+	Kind        string    `json:"kind"` // caught, fainted, released, deposited, withdrawn, leveledUp, evolved, learnedMove, forgotMove, heldItem
+	Personality uint32    `json:"personality"`
+	Species     string    `json:"species"`
+	Nickname    string    `json:"nickname,omitempty"`
+	Location    string    `json:"location,omitempty"` // Linked encounter, if any
+	From        string    `json:"from,omitempty"`     // Previous level, species, move or item
+	To          string    `json:"to,omitempty"`       // New level, species, move or item
+	Text        string    `json:"text"`               // e.g. "Tomp learned Mud Shot"
+}
+
+// AddEvents appends events to the run's log, filling in the location of linked encounters
+func (r *Run) AddEvents(events []Event) {
+	for _, event := range events {
+		if enc := r.FindByPersonality(event.Personality); enc != nil {
+			event.Location = enc.Location
+		}
+		r.Events = append(r.Events, event)
+	}
+}
@@ -0,0 +1,156 @@
+package runs
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LevelCap is the highest level allowed until a boss is beaten
+type LevelCap struct {
+	Name     string   `json:"name"` // e.g. "Roxanne"
+	Level    int      `json:"level"`
+	Badge    int      `json:"badge,omitempty"`    // Badge the boss awards (1-8), if any
+	Trainers []string `json:"trainers,omitempty"` // Trainer IDs for the boss; beating any of them counts
+}
+
+// LevelCapRules lists the level caps in the order the bosses are fought
+type LevelCapRules struct {
+	Preset string     `json:"preset,omitempty"`
+	Caps   []LevelCap `json:"caps"`
+}
+
+// Progress is how far into the game the player is
+type Progress struct {
+	Badges   int      `json:"badges"`
+	Defeated []string `json:"defeated,omitempty"` // Trainer IDs beaten
+}
+
+// Validate checks the caps' levels and badges
+// Levels can't go down from one cap to the next: a team at the last cap would be over the
+// next one before it had a chance to fight that boss
+func (l *LevelCapRules) Validate() error {
+	for i, c := range l.Caps {
+		if c.Level < 1 || c.Level > 100 {
+			return fmt.Errorf("level cap %d (%s): level must be between 1 and 100", i+1, c.Name)
+		}
+		if i > 0 && c.Level < l.Caps[i-1].Level {
+			return fmt.Errorf("level cap %d (%s): level %d is below the previous cap's %d", i+1, c.Name, c.Level, l.Caps[i-1].Level)
+		}
+		if c.Badge < 0 || c.Badge > 8 {
+			return fmt.Errorf("level cap %d (%s): badge must be between 0 (none) and 8", i+1, c.Name)
+		}
+	}
+	return nil
+}
+
+// CurrentCap returns the cap for the next boss, or nil once every boss is beaten
+// A boss counts as beaten when its badge is owned, one of its trainers is defeated, or a
+// later boss has been beaten
+func (l *LevelCapRules) CurrentCap(progress Progress) *LevelCap {
+	defeated := make(map[string]bool, len(progress.Defeated))
+	for _, id := range progress.Defeated {
+		defeated[id] = true
+	}
+
+	next := 0
+	for i, c := range l.Caps {
+		if c.Badge > 0 && progress.Badges >= c.Badge {
+			next = i + 1
+			continue
+		}
+		for _, id := range c.Trainers {
+			if defeated[id] {
+				next = i + 1
+				break
+			}
+		}
+	}
+
+	if next >= len(l.Caps) {
+		return nil
+	}
+	return &l.Caps[next]
+}
+
+// levelCapPresets are the built-in level caps: the level of each boss's strongest Pokemon
+// A boss weaker than one fought before it keeps the earlier cap, so caps never go down
+var levelCapPresets = map[string][]LevelCap{
+	// Gym leaders, the Elite Four and the Champion
+	"emerald": {
+		{Name: "Roxanne", Level: 15, Badge: 1, Trainers: []string{"emerald-roxanne"}},
+		{Name: "Brawly", Level: 19, Badge: 2, Trainers: []string{"emerald-brawly"}},
+		{Name: "Wattson", Level: 24, Badge: 3, Trainers: []string{"emerald-wattson"}},
+		{Name: "Flannery", Level: 29, Badge: 4, Trainers: []string{"emerald-flannery"}},
+		{Name: "Norman", Level: 31, Badge: 5, Trainers: []string{"emerald-norman"}},
+		{Name: "Winona", Level: 33, Badge: 6, Trainers: []string{"emerald-winona"}},
+		{Name: "Tate & Liza", Level: 42, Badge: 7, Trainers: []string{"emerald-tateandliza"}},
+		{Name: "Juan", Level: 46, Badge: 8, Trainers: []string{"emerald-juan"}},
+		{Name: "Sidney", Level: 49, Trainers: []string{"emerald-sidney"}},
+		{Name: "Phoebe", Level: 51, Trainers: []string{"emerald-phoebe"}},
+		{Name: "Glacia", Level: 53, Trainers: []string{"emerald-glacia"}},
+		{Name: "Drake", Level: 55, Trainers: []string{"emerald-drake"}},
+		{Name: "Wallace", Level: 58, Trainers: []string{"emerald-wallace"}},
+	},
+	// Every boss battle, including the rival and Team Magma/Aqua
+	"emerald-hardcore": {
+		{Name: "Rival (Route 103)", Level: 5, Trainers: rivalTrainers("route103")},
+		{Name: "Roxanne", Level: 15, Badge: 1, Trainers: []string{"emerald-roxanne"}},
+		{Name: "Rival (Rustboro)", Level: 15, Trainers: rivalTrainers("rustboro")},
+		{Name: "Brawly", Level: 19, Badge: 2, Trainers: []string{"emerald-brawly"}},
+		{Name: "Rival (Route 110)", Level: 20, Trainers: rivalTrainers("route110")},
+		{Name: "Wally (Mauville)", Level: 20, Trainers: []string{"emerald-wally-mauville"}},
+		{Name: "Wattson", Level: 24, Badge: 3, Trainers: []string{"emerald-wattson"}},
+		{Name: "Tabitha (Mt. Chimney)", Level: 24, Trainers: []string{"emerald-tabitha-mtchimney"}},
+		{Name: "Maxie (Mt. Chimney)", Level: 25, Trainers: []string{"emerald-maxie-mtchimney"}},
+		{Name: "Flannery", Level: 29, Badge: 4, Trainers: []string{"emerald-flannery"}},
+		{Name: "Norman", Level: 31, Badge: 5, Trainers: []string{"emerald-norman"}},
+		{Name: "Shelly (Weather Institute)", Level: 31, Trainers: []string{"emerald-shelly-weatherinstitute"}},
+		{Name: "Rival (Route 119)", Level: 31, Trainers: rivalTrainers("route119")},
+		{Name: "Winona", Level: 33, Badge: 6, Trainers: []string{"emerald-winona"}},
+		{Name: "Rival (Lilycove)", Level: 34, Trainers: rivalTrainers("lilycove")},
+		{Name: "Tabitha (Magma Hideout)", Level: 34, Trainers: []string{"emerald-tabitha-magmahideout"}},
+		{Name: "Maxie (Magma Hideout)", Level: 39, Trainers: []string{"emerald-maxie-magmahideout"}},
+		{Name: "Matt (Aqua Hideout)", Level: 39, Trainers: []string{"emerald-matt-aquahideout"}},
+		{Name: "Tate & Liza", Level: 42, Badge: 7, Trainers: []string{"emerald-tateandliza"}},
+		{Name: "Tabitha (Space Center)", Level: 42, Trainers: []string{"emerald-tabitha-spacecenter"}},
+		{Name: "Maxie (Space Center)", Level: 44, Trainers: []string{"emerald-maxie-spacecenter"}},
+		{Name: "Archie (Seafloor Cavern)", Level: 44, Trainers: []string{"emerald-archie-seafloorcavern"}},
+		{Name: "Juan", Level: 46, Badge: 8, Trainers: []string{"emerald-juan"}},
+		{Name: "Wally (Victory Road)", Level: 46, Trainers: []string{"emerald-wally-victoryroad"}},
+		{Name: "Sidney", Level: 49, Trainers: []string{"emerald-sidney"}},
+		{Name: "Phoebe", Level: 51, Trainers: []string{"emerald-phoebe"}},
+		{Name: "Glacia", Level: 53, Trainers: []string{"emerald-glacia"}},
+		{Name: "Drake", Level: 55, Trainers: []string{"emerald-drake"}},
+		{Name: "Wallace", Level: 58, Trainers: []string{"emerald-wallace"}},
+	},
+}
+
+// rivalTrainers returns the trainer IDs of a rival battle for every starter
+func rivalTrainers(location string) []string {
+	ids := make([]string, 0, 3)
+	for _, starter := range []string{"treecko", "torchic", "mudkip"} {
+		ids = append(ids, "emerald-rival-"+location+"-"+starter)
+	}
+	return ids
+}
+
+// LevelCapPreset returns a copy of a built-in level cap preset
+func LevelCapPreset(name string) (*LevelCapRules, bool) {
+	caps, ok := levelCapPresets[name]
+	if !ok {
+		return nil, false
+	}
+	rules := &LevelCapRules{Preset: name, Caps: make([]LevelCap, len(caps))}
+	copy(rules.Caps, caps)
+	return rules, true
+}
+
+// LevelCapPresets returns the names of the built-in presets
+func LevelCapPresets() []string {
+	names := make([]string, 0, len(levelCapPresets))
+	for name := range levelCapPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
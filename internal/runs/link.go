@@ -0,0 +1,103 @@
+package runs
+
+import (
+	"strings"
+
+	"nuzlocke/internal/data"
+)
+
+// SavePokemon is a Pokemon read from a save file, used to link the save to a run's encounters
+type SavePokemon struct {
+	Personality uint32
+	Species     string
+	Nickname    string
+	InParty     bool
+}
+
+// LinkResult maps each save Pokemon's personality value to the location it was encountered on
+type LinkResult struct {
+	Linked   map[uint32]string `json:"linked"`
+	Unlinked []uint32          `json:"unlinked,omitempty"` // Save Pokemon with no matching encounter
+}
+
+// LinkSave links save file Pokemon to the run's encounters by personality value and
+// updates party/box membership. Pokemon not yet linked are matched to an obtained encounter
+// of the same species (or family, when sameFamily is given) that has no personality value yet.
+// Graveyard membership is never changed by a save.
+func (r *Run) LinkSave(pokemon []SavePokemon, sameFamily func(a, b string) bool) *LinkResult {
+	result := &LinkResult{Linked: make(map[uint32]string)}
+
+	// Link Pokemon that already have a personality value first, so they can't be
+	// claimed by a species match
+	var pending []SavePokemon
+	for _, p := range pokemon {
+		if p.Personality == 0 {
+			continue
+		}
+		if enc := r.FindByPersonality(p.Personality); enc != nil {
+			r.updateFromSave(enc, p)
+			result.Linked[p.Personality] = enc.Location
+			continue
+		}
+		pending = append(pending, p)
+	}
+
+	for _, p := range pending {
+		enc := r.findUnlinked(p, sameFamily)
+		if enc == nil {
+			result.Unlinked = append(result.Unlinked, p.Personality)
+			continue
+		}
+		enc.Personality = p.Personality
+		r.updateFromSave(enc, p)
+		result.Linked[p.Personality] = enc.Location
+	}
+
+	return result
+}
+
+// findUnlinked returns the first obtained encounter (by location) that matches the Pokemon
+// and isn't linked yet, preferring a matching nickname
+func (r *Run) findUnlinked(p SavePokemon, sameFamily func(a, b string) bool) *Encounter {
+	var match *Encounter
+	for _, m := range []Membership{MemberParty, MemberBox, ""} {
+		for _, enc := range r.Members(m) {
+			if enc.Personality != 0 || !enc.Status.Obtained() {
+				continue
+			}
+			if !speciesMatch(enc.Species, p.Species, sameFamily) {
+				continue
+			}
+			if enc.Nickname != "" && strings.EqualFold(enc.Nickname, p.Nickname) {
+				return enc
+			}
+			if match == nil {
+				match = enc
+			}
+		}
+	}
+	return match
+}
+
+// updateFromSave updates an encounter's membership and nickname from the save
+func (r *Run) updateFromSave(enc *Encounter, p SavePokemon) {
+	if enc.Nickname == "" {
+		enc.Nickname = p.Nickname
+	}
+	if enc.Membership == MemberGraveyard {
+		return
+	}
+	if p.InParty {
+		enc.Membership = MemberParty
+	} else {
+		enc.Membership = MemberBox
+	}
+}
+
+// speciesMatch compares species names by ID
+func speciesMatch(a, b string, sameFamily func(a, b string) bool) bool {
+	if data.ToID(a) == data.ToID(b) {
+		return true
+	}
+	return sameFamily != nil && sameFamily(a, b)
+}
@@ -0,0 +1,193 @@
+package runs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EncounterStatus is the outcome of a location's encounter
+type EncounterStatus string
+
+const (
+	StatusCaught      EncounterStatus = "caught"
+	StatusFainted     EncounterStatus = "fainted"      // Knocked out before it could be caught
+	StatusMissed      EncounterStatus = "missed"       // Fled, or the ball failed
+	StatusGift        EncounterStatus = "gift"         // Starter, gift or trade
+	StatusDupeSkipped EncounterStatus = "dupe-skipped" // Skipped under the dupes clause
+)
+
+// Valid returns true if the status is a known encounter outcome
+func (s EncounterStatus) Valid() bool {
+	switch s {
+	case StatusCaught, StatusFainted, StatusMissed, StatusGift, StatusDupeSkipped:
+		return true
+	}
+	return false
+}
+
+// Obtained returns true if the encounter gave the player a Pokemon
+func (s EncounterStatus) Obtained() bool {
+	return s == StatusCaught || s == StatusGift
+}
+
+// Membership is where an obtained Pokemon currently is
+type Membership string
+
+const (
+	MemberParty     Membership = "party"
+	MemberBox       Membership = "box"
+	MemberGraveyard Membership = "graveyard"
+)
+
+// Valid returns true if the membership is empty or a known location
+func (m Membership) Valid() bool {
+	switch m {
+	case "", MemberParty, MemberBox, MemberGraveyard:
+		return true
+	}
+	return false
+}
+
+// Ruleset holds the rules a run is played under
+type Ruleset struct {
+	Name          string         `json:"name,omitempty"`          // e.g. "Standard Nuzlocke"
+	DupesClause   bool           `json:"dupesClause"`             // Skip species (and evolution families) already encountered
+	ShinyClause   bool           `json:"shinyClause"`             // Shinies may always be caught
+	SpeciesClause bool           `json:"speciesClause,omitempty"` // Only one of each species may be obtained
+	NoItemsBattle bool           `json:"noItemsInBattle,omitempty"`
+	SetMode       bool           `json:"setMode,omitempty"`
+	Exemptions    []string       `json:"exemptions,omitempty"` // Species (and their families) the dupes clause never blocks
+	LevelCaps     *LevelCapRules `json:"levelCaps,omitempty"`  // Hardcore level caps, one per boss
+	Custom        []string       `json:"custom,omitempty"`     // Free-form house rules
+}
+
+// Encounter is the first encounter on a location and what became of it
+type Encounter struct {
+	Location    string          `json:"location"`
+	Species     string          `json:"species"`
+	Status      EncounterStatus `json:"status"`
+	Level       int             `json:"level,omitempty"`
+	Nickname    string          `json:"nickname,omitempty"`
+	Personality uint32          `json:"personality,omitempty"` // Links the encounter to the Pokemon in the save file
+	Membership  Membership      `json:"membership,omitempty"`  // Only set for obtained Pokemon
+	Shiny       bool            `json:"shiny,omitempty"`
+	Notes       string          `json:"notes,omitempty"`
+	CaughtAt    *time.Time      `json:"caughtAt,omitempty"`
+	DiedAt      *time.Time      `json:"diedAt,omitempty"`
+}
+
+// Run is a single Nuzlocke playthrough
+type Run struct {
+	ID         string                `json:"id"`
+	Name       string                `json:"name"`
+	Game       string                `json:"game"` // e.g. "emerald", "firered", or a ROM hack
+	Ruleset    Ruleset               `json:"ruleset"`
+	Encounters map[string]*Encounter `json:"encounters"`       // Keyed by location ID
+	Events     []Event               `json:"events,omitempty"` // Changes between uploaded saves, oldest first
+	CreatedAt  time.Time             `json:"createdAt"`
+	UpdatedAt  time.Time             `json:"updatedAt"`
+}
+
+// NewRun creates an empty run with the standard rules
+func NewRun(name, game string) *Run {
+	now := time.Now().UTC()
+	return &Run{
+		Name: name,
+		Game: game,
+		Ruleset: Ruleset{
+			Name:        "Standard Nuzlocke",
+			DupesClause: true,
+			ShinyClause: true,
+		},
+		Encounters: make(map[string]*Encounter),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Validate checks the run's level caps and its encounters for unknown statuses and memberships
+func (r *Run) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("run name is required")
+	}
+	if r.Ruleset.LevelCaps != nil {
+		if err := r.Ruleset.LevelCaps.Validate(); err != nil {
+			return err
+		}
+	}
+	for location, enc := range r.Encounters {
+		if err := enc.Validate(); err != nil {
+			return fmt.Errorf("encounter %q: %w", location, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks an encounter's status and membership
+func (e *Encounter) Validate() error {
+	if e.Location == "" {
+		return fmt.Errorf("location is required")
+	}
+	if !e.Status.Valid() {
+		return fmt.Errorf("unknown status %q", e.Status)
+	}
+	if !e.Membership.Valid() {
+		return fmt.Errorf("unknown membership %q", e.Membership)
+	}
+	if e.Membership != "" && !e.Status.Obtained() {
+		return fmt.Errorf("only caught or gift Pokemon can be in the party, box or graveyard")
+	}
+	return nil
+}
+
+// SetEncounter records the encounter for a location, replacing any previous one
+func (r *Run) SetEncounter(enc *Encounter) error {
+	if enc.Membership == "" && enc.Status.Obtained() {
+		enc.Membership = MemberBox
+	}
+	if err := enc.Validate(); err != nil {
+		return err
+	}
+	if r.Encounters == nil {
+		r.Encounters = make(map[string]*Encounter)
+	}
+	if enc.Status.Obtained() && enc.CaughtAt == nil {
+		now := time.Now().UTC()
+		enc.CaughtAt = &now
+	}
+	if enc.Membership == MemberGraveyard && enc.DiedAt == nil {
+		now := time.Now().UTC()
+		enc.DiedAt = &now
+	}
+	r.Encounters[enc.Location] = enc
+	return nil
+}
+
+// Members returns the obtained Pokemon with the given membership, sorted by location
+func (r *Run) Members(m Membership) []*Encounter {
+	members := []*Encounter{}
+	for _, enc := range r.Encounters {
+		if enc.Membership == m {
+			members = append(members, enc)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Location < members[j].Location
+	})
+	return members
+}
+
+// FindByPersonality returns the encounter linked to a personality value, or nil
+func (r *Run) FindByPersonality(personality uint32) *Encounter {
+	if personality == 0 {
+		return nil
+	}
+	for _, enc := range r.Encounters {
+		if enc.Personality == personality {
+			return enc
+		}
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package runs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when a run doesn't exist
+var ErrNotFound = errors.New("run not found")
+
+// ErrNoSave is returned when no save file has been linked to a run yet
+var ErrNoSave = errors.New("no save file linked to run")
+
+// Store persists runs as JSON files, one file per run
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewStore creates a Store that keeps runs in dir, creating the directory if needed
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create runs directory: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Dir returns the directory runs are stored in
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// List returns every saved run, most recently updated first
+func (s *Store) List() ([]*Run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read runs directory: %w", err)
+	}
+
+	runs := make([]*Run, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		run, err := s.load(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].UpdatedAt.After(runs[j].UpdatedAt)
+	})
+	return runs, nil
+}
+
+// Get loads a run by ID
+func (s *Store) Get(id string) (*Run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(id)
+}
+
+// Create assigns a new ID to the run and saves it
+func (s *Store) Create(run *Run) error {
+	if err := run.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+	run.ID = id
+	if run.Encounters == nil {
+		run.Encounters = make(map[string]*Encounter)
+	}
+	now := time.Now().UTC()
+	run.CreatedAt = now
+	run.UpdatedAt = now
+	return s.write(run)
+}
+
+// Save writes an existing run
+func (s *Store) Save(run *Run) error {
+	if err := run.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := os.Stat(s.path(run.ID)); err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	run.UpdatedAt = time.Now().UTC()
+	return s.write(run)
+}
+
+// Update loads a run, applies fn to it and saves it, holding the lock throughout
+// so concurrent updates aren't lost
+func (s *Store) Update(id string, fn func(run *Run) error) (*Run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run, err := s.load(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(run); err != nil {
+		return nil, err
+	}
+	if err := run.Validate(); err != nil {
+		return nil, err
+	}
+	run.ID = id
+	run.UpdatedAt = time.Now().UTC()
+	if err := s.write(run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// LinkSave is Update for a newly uploaded save: fn is given the run and its previous save
+// (nil if none), then the new save replaces the old one. Holding the lock throughout means
+// concurrent uploads each see the save stored before them.
+func (s *Store) LinkSave(id string, save []byte, fn func(run *Run, previous []byte) error) (*Run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run, err := s.load(id)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.readSave(id)
+	if err != nil && !errors.Is(err, ErrNoSave) {
+		return nil, err
+	}
+	if err := fn(run, previous); err != nil {
+		return nil, err
+	}
+	if err := run.Validate(); err != nil {
+		return nil, err
+	}
+	run.ID = id
+	run.UpdatedAt = time.Now().UTC()
+	if err := s.write(run); err != nil {
+		return nil, err
+	}
+	if err := s.writeFile(s.savePath(id), id, save); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Delete removes a run
+func (s *Store) Delete(id string) error {
+	if !validID(id) {
+		return ErrNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(id)); err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete run: %w", err)
+	}
+	os.Remove(s.savePath(id))
+	return nil
+}
+
+// ReadSave returns the latest save file linked to a run
+func (s *Store) ReadSave(id string) ([]byte, error) {
+	if !validID(id) {
+		return nil, ErrNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readSave(id)
+}
+
+// readSave reads a run's latest save file (caller must hold the lock)
+func (s *Store) readSave(id string) ([]byte, error) {
+	save, err := os.ReadFile(s.savePath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoSave
+		}
+		return nil, fmt.Errorf("failed to read save for run %s: %w", id, err)
+	}
+	return save, nil
+}
+
+// load reads a run from disk (caller must hold the lock)
+func (s *Store) load(id string) (*Run, error) {
+	if !validID(id) {
+		return nil, ErrNotFound
+	}
+
+	raw, err := os.ReadFile(s.path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read run %s: %w", id, err)
+	}
+
+	var run Run
+	if err := json.Unmarshal(raw, &run); err != nil {
+		return nil, fmt.Errorf("failed to parse run %s: %w", id, err)
+	}
+	run.ID = id
+	if run.Encounters == nil {
+		run.Encounters = make(map[string]*Encounter)
+	}
+	return &run, nil
+}
+
+// write saves a run as JSON (caller must hold the lock)
+func (s *Store) write(run *Run) error {
+	if !validID(run.ID) {
+		return fmt.Errorf("invalid run ID %q", run.ID)
+	}
+
+	raw, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode run: %w", err)
+	}
+	return s.writeFile(s.path(run.ID), run.ID, raw)
+}
+
+// writeFile writes a file atomically: the contents are written to a temporary file in the
+// same directory, synced, then renamed over the old file (caller must hold the lock)
+func (s *Store) writeFile(path, id string, contents []byte) error {
+	tmp, err := os.CreateTemp(s.dir, id+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // No-op once renamed
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync %s: %w", filepath.Base(path), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", filepath.Base(path), err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("failed to save %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// path returns the file path for a run
+func (s *Store) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// savePath returns the file path for a run's latest save file
+func (s *Store) savePath(id string) string {
+	return filepath.Join(s.dir, id+".sav")
+}
+
+// newID generates a random run ID
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate run ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// validID returns true if id is safe to use as a file name
+func validID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,30 @@
+package savefile
+
+// Species IDs of the Complete FireRed Upgrade (CFRU) engine, numbered by its Dynamic Pokemon Expansion
+// The vanilla IDs are kept up to Chimecho and the Egg; the Unown letters follow as species of their
+// own and the species from Turtwig on are numbered in National Dex order
+const (
+	cfruFirstUnown    = 413 // Unown B; the letters run to Unown ? at 439
+	cfruLastUnown     = 439
+	cfruFirstNational = 440 // Turtwig
+	cfruLastNational  = 862 // Melmetal; the Gen 8 species and forms after it aren't decoded
+)
+
+// cfruToNationalDex maps CFRU species IDs that aren't National Dex numbers to National Dex numbers
+var cfruToNationalDex = cfruSpecies()
+
+// cfruSpecies builds cfruToNationalDex from the vanilla table, the Unown letters and the
+// species numbered in National Dex order from Turtwig
+func cfruSpecies() map[int]int {
+	species := make(map[int]int, len(gen3ToNationalDex)+cfruLastNational-cfruFirstUnown+1)
+	for id, num := range gen3ToNationalDex {
+		species[id] = num
+	}
+	for id := cfruFirstUnown; id <= cfruLastUnown; id++ {
+		species[id] = 201
+	}
+	for id := cfruFirstNational; id <= cfruLastNational; id++ {
+		species[id] = id - cfruFirstNational + 387
+	}
+	return species
+}
@@ -0,0 +1,199 @@
+package savefile
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Save layout constants shared by every Gen 3 game
+const (
+	sectorSize      = 0x1000
+	sectionsPerSlot = 14
+	saveSignature   = 0x08012025
+)
+
+// saveSlots are the base addresses of save A and save B
+var saveSlots = []int{0x0000, 0xE000}
+
+// sectionDataSizes lists the number of checksummed bytes in each section
+// Only sections 0 and 4 differ between games: Emerald, FireRed/LeafGreen, Ruby/Sapphire, then
+// the whole sector for ROM hacks that resize them (sectors are zero-padded, so the sum matches)
+var sectionDataSizes = [sectionsPerSlot][]int{
+	{3884, 3876, 2192, 3968},
+	{3968}, {3968}, {3968},
+	{3848, 3816, 3136, 3968},
+	{3968}, {3968}, {3968}, {3968}, {3968}, {3968}, {3968}, {3968},
+	{2000},
+}
+
+// slotNames label the save slots in integrity reports
+var slotNames = []string{"A", "B"}
+
+// SlotReport is the result of checking one save slot
+type SlotReport struct {
+	Slot            string   `json:"slot"`            // "A" or "B"
+	SaveIndex       uint32   `json:"saveIndex"`       // Incremented on every save; the higher index is newer
+	Valid           bool     `json:"valid"`           // Every section present once with a valid signature and checksum
+	Empty           bool     `json:"empty"`           // No sector has a save signature, e.g. a slot never written
+	BadSections     []int    `json:"badSections"`     // Sections with a checksum mismatch or stored twice
+	MissingSections []int    `json:"missingSections"` // Sections not found with a valid signature
+	Problems        []string `json:"problems"`        // Human-readable description of each problem
+}
+
+// Integrity reports the state of both save slots and which one was parsed
+type Integrity struct {
+	Slots    []SlotReport `json:"slots"`
+	UsedSlot string       `json:"usedSlot"`
+	Valid    bool         `json:"valid"`    // The parsed slot passed every check
+	FellBack bool         `json:"fellBack"` // The newer slot was corrupt so the older one was parsed
+}
+
+// CheckIntegrity checks both save slots and picks the one to parse: the newer slot, or the
+// older one if only the newer slot is corrupt. Returns the chosen slot's base address.
+func CheckIntegrity(data []byte) (*Integrity, int) {
+	integrity := &Integrity{Slots: make([]SlotReport, len(saveSlots))}
+	for i, slotBase := range saveSlots {
+		integrity.Slots[i] = checkSlot(data, slotBase)
+		integrity.Slots[i].Slot = slotNames[i]
+	}
+
+	newer, older := 0, 1
+	if integrity.Slots[1].SaveIndex > integrity.Slots[0].SaveIndex {
+		newer, older = 1, 0
+	}
+	used := newer
+	if !integrity.Slots[newer].Valid && integrity.Slots[older].Valid {
+		used = older
+		integrity.FellBack = true
+	}
+
+	integrity.UsedSlot = integrity.Slots[used].Slot
+	integrity.Valid = integrity.Slots[used].Valid
+	return integrity, saveSlots[used]
+}
+
+// checkSlot checks every sector of a save slot
+// The save index is read from the first sector with a valid signature
+func checkSlot(data []byte, slotBase int) SlotReport {
+	report := SlotReport{
+		BadSections:     []int{},
+		MissingSections: []int{},
+		Problems:        []string{},
+	}
+	seen := make(map[int]bool, sectionsPerSlot)
+	indexRead := false
+
+	for sectorIdx := 0; sectorIdx < sectionsPerSlot; sectorIdx++ {
+		sectorBase := slotBase + (sectorIdx * sectorSize)
+		if sectorBase+sectorSize > len(data) {
+			break
+		}
+		footer := data[sectorBase+0xFF4 : sectorBase+sectorSize]
+
+		sectionID := int(binary.LittleEndian.Uint16(footer[0:2]))
+		checksum := binary.LittleEndian.Uint16(footer[2:4])
+		signature := binary.LittleEndian.Uint32(footer[4:8])
+
+		if signature != saveSignature {
+			report.Problems = append(report.Problems, fmt.Sprintf("sector %d has no save signature", sectorIdx))
+			continue
+		}
+		if !indexRead {
+			report.SaveIndex = binary.LittleEndian.Uint32(footer[8:12])
+			indexRead = true
+		}
+		if sectionID >= sectionsPerSlot {
+			report.Problems = append(report.Problems, fmt.Sprintf("sector %d has invalid section ID %d", sectorIdx, sectionID))
+			continue
+		}
+		if seen[sectionID] {
+			report.BadSections = append(report.BadSections, sectionID)
+			report.Problems = append(report.Problems, fmt.Sprintf("section %d appears more than once", sectionID))
+			continue
+		}
+		seen[sectionID] = true
+
+		valid := false
+		for _, size := range sectionDataSizes[sectionID] {
+			if sectionChecksum(data[sectorBase:sectorBase+size]) == checksum {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			report.BadSections = append(report.BadSections, sectionID)
+			report.Problems = append(report.Problems, fmt.Sprintf("section %d checksum mismatch", sectionID))
+		}
+	}
+
+	if !indexRead {
+		report.Empty = true
+		report.Problems = []string{"slot has no saved data"}
+	}
+	for sectionID := 0; sectionID < sectionsPerSlot; sectionID++ {
+		if !seen[sectionID] {
+			report.MissingSections = append(report.MissingSections, sectionID)
+			if !report.Empty {
+				report.Problems = append(report.Problems, fmt.Sprintf("section %d is missing", sectionID))
+			}
+		}
+	}
+
+	report.Valid = len(report.Problems) == 0
+	return report
+}
+
+// sectionOffset returns the address of a section in a save slot, or -1 if it's missing
+func sectionOffset(data []byte, slotBase, sectionID int) int {
+	for sectorIdx := 0; sectorIdx < sectionsPerSlot; sectorIdx++ {
+		sectorBase := slotBase + (sectorIdx * sectorSize)
+		if sectorBase+sectorSize > len(data) {
+			break
+		}
+		if int(binary.LittleEndian.Uint16(data[sectorBase+0xFF4:sectorBase+0xFF6])) == sectionID {
+			return sectorBase
+		}
+	}
+	return -1
+}
+
+// sectionChecksum returns the checksum of a section's data: the 32-bit sum of its words,
+// with the high and low halves added together
+func sectionChecksum(data []byte) uint16 {
+	var sum uint32
+	for i := 0; i+4 <= len(data); i += 4 {
+		sum += binary.LittleEndian.Uint32(data[i : i+4])
+	}
+	return uint16((sum >> 16) + (sum & 0xFFFF))
+}
+
+// ValidateSave checks the newest data of a Gen 1-4 save, see ValidateGen3Save,
+// ValidateGen4Save and ValidateRetroSave
+func ValidateSave(data []byte) error {
+	switch {
+	case IsGen4Save(data):
+		return ValidateGen4Save(data)
+	case IsRetroSave(data):
+		return ValidateRetroSave(data)
+	}
+	return ValidateGen3Save(data)
+}
+
+// ValidateGen3Save checks that the newest save slot has every section exactly once, each with
+// a valid signature and checksum. A save caught halfway through being written fails.
+func ValidateGen3Save(data []byte) error {
+	if len(data) < 0x20000 {
+		return fmt.Errorf("save file too small")
+	}
+
+	integrity, _ := CheckIntegrity(data)
+	newest := integrity.Slots[0]
+	if integrity.Slots[1].SaveIndex > newest.SaveIndex {
+		newest = integrity.Slots[1]
+	}
+	if !newest.Valid {
+		return errors.New(newest.Problems[0])
+	}
+	return nil
+}
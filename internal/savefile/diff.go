@@ -0,0 +1,193 @@
+package savefile
+
+// ChangeKind is the kind of change between two saves
+type ChangeKind string
+
+const (
+	ChangeCaught      ChangeKind = "caught"      // New Pokemon in the party or PC
+	ChangeFainted     ChangeKind = "fainted"     // Party HP hit 0
+	ChangeReleased    ChangeKind = "released"    // No longer in the party or PC
+	ChangeDeposited   ChangeKind = "deposited"   // Moved from the party to the PC
+	ChangeWithdrawn   ChangeKind = "withdrawn"   // Moved from the PC to the party
+	ChangeLeveledUp   ChangeKind = "leveledUp"   // Level went up
+	ChangeEvolved     ChangeKind = "evolved"     // Species changed
+	ChangeLearnedMove ChangeKind = "learnedMove" // Move added
+	ChangeForgotMove  ChangeKind = "forgotMove"  // Move removed
+	ChangeHeldItem    ChangeKind = "heldItem"    // Held item changed (0 = none)
+)
+
+// Change is one difference between two saves for a single Pokemon
+// Old and New hold the level, species number, move number or item number, depending on the kind
+type Change struct {
+	Kind        ChangeKind `json:"kind"`
+	Personality uint32     `json:"personality"`
+	OTID        uint32     `json:"otId"`
+	SpeciesNum  int        `json:"speciesNum"` // Species in the newer save (older save if released)
+	Nickname    string     `json:"nickname"`
+	Old         int        `json:"old,omitempty"`
+	New         int        `json:"new,omitempty"`
+}
+
+// pokemonKey identifies a Pokemon across saves
+type pokemonKey struct {
+	personality uint32
+	otID        uint32
+}
+
+// snapshot is the state of a Pokemon in the party or PC that a diff compares
+type snapshot struct {
+	key        pokemonKey
+	speciesNum int
+	nickname   string
+	level      int
+	experience uint32
+	moveNums   []int
+	itemNum    int
+	inParty    bool
+	currentHP  int
+}
+
+// Diff compares two saves, matching Pokemon by personality value and OT ID
+// Gen 1 and 2 Pokemon are keyed by their DVs, so two from the same trainer can share a key;
+// those are paired in save order, preferring a Pokemon of the same species.
+// Changes are ordered by the Pokemon's position in the newer save (party first, then the PC),
+// followed by released Pokemon in their order in the older save
+func Diff(older, newer *ParseResult) []Change {
+	before := snapshots(older)
+	after := snapshots(newer)
+
+	beforeByKey := make(map[pokemonKey][]int, len(before))
+	for i, s := range before {
+		beforeByKey[s.key] = append(beforeByKey[s.key], i)
+	}
+	matched := make([]bool, len(before))
+
+	changes := []Change{}
+	for _, cur := range after {
+		candidates := beforeByKey[cur.key]
+		if len(candidates) == 0 {
+			changes = append(changes, change(ChangeCaught, cur, 0, 0))
+			if cur.inParty && cur.currentHP == 0 {
+				changes = append(changes, change(ChangeFainted, cur, 0, 0))
+			}
+			continue
+		}
+		pick := 0
+		for j, i := range candidates {
+			if before[i].speciesNum == cur.speciesNum {
+				pick = j
+				break
+			}
+		}
+		i := candidates[pick]
+		beforeByKey[cur.key] = append(candidates[:pick:pick], candidates[pick+1:]...)
+		matched[i] = true
+		changes = append(changes, compare(before[i], cur)...)
+	}
+
+	for i, prev := range before {
+		if !matched[i] {
+			changes = append(changes, change(ChangeReleased, prev, 0, 0))
+		}
+	}
+	return changes
+}
+
+// compare returns the changes to one Pokemon between two saves
+func compare(prev, cur snapshot) []Change {
+	var changes []Change
+
+	if prev.speciesNum != cur.speciesNum {
+		changes = append(changes, change(ChangeEvolved, cur, prev.speciesNum, cur.speciesNum))
+	}
+	// Box levels are estimated from experience, so only trust a level change backed by experience
+	if cur.level > prev.level && cur.experience > prev.experience {
+		changes = append(changes, change(ChangeLeveledUp, cur, prev.level, cur.level))
+	}
+
+	for _, move := range cur.moveNums {
+		if !containsMove(prev.moveNums, move) {
+			changes = append(changes, change(ChangeLearnedMove, cur, 0, move))
+		}
+	}
+	for _, move := range prev.moveNums {
+		if !containsMove(cur.moveNums, move) {
+			changes = append(changes, change(ChangeForgotMove, cur, move, 0))
+		}
+	}
+
+	if prev.itemNum != cur.itemNum {
+		changes = append(changes, change(ChangeHeldItem, cur, prev.itemNum, cur.itemNum))
+	}
+
+	switch {
+	case prev.inParty && !cur.inParty:
+		changes = append(changes, change(ChangeDeposited, cur, 0, 0))
+	case !prev.inParty && cur.inParty:
+		changes = append(changes, change(ChangeWithdrawn, cur, 0, 0))
+	}
+	if cur.inParty && cur.currentHP == 0 && (!prev.inParty || prev.currentHP > 0) {
+		changes = append(changes, change(ChangeFainted, cur, 0, 0))
+	}
+
+	return changes
+}
+
+// change builds a Change for a Pokemon
+func change(kind ChangeKind, s snapshot, from, to int) Change {
+	return Change{
+		Kind:        kind,
+		Personality: s.key.personality,
+		OTID:        s.key.otID,
+		SpeciesNum:  s.speciesNum,
+		Nickname:    s.nickname,
+		Old:         from,
+		New:         to,
+	}
+}
+
+// snapshots lists every Pokemon in a save's party and PC
+func snapshots(result *ParseResult) []snapshot {
+	if result == nil {
+		return nil
+	}
+
+	var list []snapshot
+	for _, p := range result.Party {
+		list = append(list, snapshot{
+			key:        pokemonKey{p.Personality, p.OTID},
+			speciesNum: p.SpeciesNum,
+			nickname:   p.Nickname,
+			level:      p.Level,
+			experience: p.Experience,
+			moveNums:   p.MoveNums,
+			itemNum:    p.ItemNum,
+			inParty:    true,
+			currentHP:  p.CurrentHP,
+		})
+	}
+	for _, box := range result.Boxes {
+		for _, p := range box {
+			list = append(list, snapshot{
+				key:        pokemonKey{p.Personality, p.OTID},
+				speciesNum: p.SpeciesNum,
+				nickname:   p.Nickname,
+				level:      p.Level,
+				experience: p.Experience,
+				moveNums:   p.MoveNums,
+				itemNum:    p.ItemNum,
+			})
+		}
+	}
+	return list
+}
+
+// containsMove returns true if moves contains move
+func containsMove(moves []int, move int) bool {
+	for _, m := range moves {
+		if m == move {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package savefile
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// .pk3 sizes: the full party record, or the box record without the party data
+const (
+	PK3PartySize = 100
+	PK3BoxSize   = 80
+)
+
+// ErrNotGen3 is returned when exporting a Pokemon that wasn't read from a Gen 3 save
+var ErrNotGen3 = errors.New("only Pokemon from Gen 3 saves can be exported as .pk3")
+
+// PK3 re-serializes a party Pokemon as a 100-byte .pk3 file
+func (p PartyPokemon) PK3() ([]byte, error) {
+	return encodePK3(p.raw, PK3PartySize)
+}
+
+// PK3 re-serializes a box Pokemon as an 80-byte .pk3 file
+func (p BoxPokemon) PK3() ([]byte, error) {
+	return encodePK3(p.raw, PK3BoxSize)
+}
+
+// pk3Record joins the unencrypted parts of a Gen 3 record: the 32-byte header, the 48 bytes
+// of substructures decrypted in their stored order, then the party data if any
+func pk3Record(parts ...[]byte) []byte {
+	record := make([]byte, 0, PK3PartySize)
+	for _, part := range parts {
+		record = append(record, part...)
+	}
+	return record
+}
+
+// encodePK3 checksums a record joined by pk3Record and puts its substructures in Growth,
+// Attacks, EVs, Misc order, which is how PKHeX stores decrypted .pk3 files
+// The checksum is the 16-bit sum of the substructures, so reordering them doesn't change it.
+func encodePK3(raw []byte, size int) ([]byte, error) {
+	if len(raw) != size {
+		return nil, ErrNotGen3
+	}
+
+	encoded := make([]byte, size)
+	copy(encoded, raw)
+
+	var sum uint16
+	for i := 32; i < 80; i += 2 {
+		sum += binary.LittleEndian.Uint16(raw[i : i+2])
+	}
+	binary.LittleEndian.PutUint16(encoded[28:30], sum)
+
+	personality := binary.LittleEndian.Uint32(raw[0:4])
+	for pos, typ := range substructOrder[personality%24] {
+		copy(encoded[32+typ*12:44+typ*12], raw[32+pos*12:44+pos*12])
+	}
+
+	return encoded, nil
+}
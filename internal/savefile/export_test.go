@@ -0,0 +1,68 @@
+package savefile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// storedRecord shuffles and encrypts a .pk3 the way the games store it, the reverse of encodePK3
+func storedRecord(pk3 []byte) []byte {
+	stored := make([]byte, len(pk3))
+	copy(stored, pk3)
+
+	personality := binary.LittleEndian.Uint32(pk3[0:4])
+	key := personality ^ binary.LittleEndian.Uint32(pk3[4:8])
+	for pos, typ := range substructOrder[personality%24] {
+		for i := 0; i < 12; i += 4 {
+			word := binary.LittleEndian.Uint32(pk3[32+typ*12+i:]) ^ key
+			binary.LittleEndian.PutUint32(stored[32+pos*12+i:], word)
+		}
+	}
+	return stored
+}
+
+func TestEncodePK3RoundTrip(t *testing.T) {
+	for _, personality := range []uint32{0x00000018, 0x12345679, 0xDEADBEEF, 0xFFFFFFF7} {
+		// A .pk3 with every substructure byte set to its own offset
+		pk3 := make([]byte, PK3PartySize)
+		binary.LittleEndian.PutUint32(pk3[0:4], personality)
+		binary.LittleEndian.PutUint32(pk3[4:8], 0x0001E240)
+		var sum uint16
+		for i := 32; i < 80; i++ {
+			pk3[i] = byte(i)
+		}
+		for i := 32; i < 80; i += 2 {
+			sum += binary.LittleEndian.Uint16(pk3[i : i+2])
+		}
+		binary.LittleEndian.PutUint16(pk3[28:30], sum)
+		for i := 80; i < PK3PartySize; i++ {
+			pk3[i] = byte(i)
+		}
+
+		stored := storedRecord(pk3)
+		decrypted, positions, ok := decryptPokemon(stored)
+		if !ok {
+			t.Fatalf("personality %08X: stored record failed its checksum", personality)
+		}
+		for typ, pos := range positions {
+			if !bytes.Equal(decrypted[pos:pos+12], pk3[32+typ*12:44+typ*12]) {
+				t.Errorf("personality %08X: substructure %d decrypted from position %d doesn't match", personality, typ, pos)
+			}
+		}
+
+		encoded, err := encodePK3(pk3Record(stored[:32], decrypted, stored[80:100]), PK3PartySize)
+		if err != nil {
+			t.Fatalf("personality %08X: %v", personality, err)
+		}
+		if !bytes.Equal(encoded, pk3) {
+			t.Errorf("personality %08X: encoded .pk3 differs from the original\n got % X\nwant % X", personality, encoded, pk3)
+		}
+	}
+}
+
+func TestEncodePK3RejectsWrongSize(t *testing.T) {
+	if _, err := encodePK3(make([]byte, PK3BoxSize), PK3PartySize); err != ErrNotGen3 {
+		t.Errorf("got %v, want ErrNotGen3", err)
+	}
+}
@@ -0,0 +1,243 @@
+package savefile
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Format is how a save encodes its Pokemon and items: as the vanilla games do or as a
+// ROM hack engine does
+type Format string
+
+const (
+	FormatAuto        Format = ""              // Detect the format from the save
+	FormatVanilla     Format = "vanilla"       // Ruby, Sapphire, Emerald, FireRed and LeafGreen
+	FormatExpansion18 Format = "expansion-1.8" // pokeemerald-expansion 1.8 and later, with the ball in Growth
+	FormatExpansion17 Format = "expansion-1.7" // pokeemerald-expansion up to 1.7, with the vanilla Growth layout
+	FormatCFRU        Format = "cfru"          // Complete FireRed Upgrade hacks such as Radical Red
+
+	// FormatExpansion is the latest pokeemerald-expansion format
+	FormatExpansion = FormatExpansion18
+)
+
+// ParseFormat returns the format with the given name; "" and "auto" mean detect it and
+// "expansion" means the latest pokeemerald-expansion format
+func ParseFormat(name string) (Format, error) {
+	switch Format(name) {
+	case FormatAuto, "auto":
+		return FormatAuto, nil
+	case "expansion":
+		return FormatExpansion, nil
+	case FormatVanilla, FormatExpansion18, FormatExpansion17, FormatCFRU:
+		return Format(name), nil
+	}
+	return "", fmt.Errorf("unknown save format %q (want vanilla, expansion, expansion-1.8, expansion-1.7, cfru or auto)", name)
+}
+
+// profile holds how a format encodes Pokemon and items, with its own species and item tables
+type profile struct {
+	format        Format
+	speciesMask   int         // Bits of the Growth species field that hold the species
+	species       map[int]int // Species IDs that aren't National Dex numbers
+	maxNationalID int         // Species IDs missing from species up to this are National Dex numbers
+	items         map[int]int // Item IDs to Showdown item numbers
+	rawItems      bool        // Item IDs missing from items are Showdown numbers already
+	packedGrowth  bool        // Growth packs the 11th and 12th nickname characters and the ball beside the experience
+	ribbonAbility bool        // 2-bit ability slot in ribbon bits 29-30 instead of the single IV word bit 31
+	keyOffset     int         // Security key offset in section 0 when it differs from the game's
+	movedPokedex  bool        // Pokedex flags aren't in section 0, so the Pokedex isn't read
+	hmItemBase    int         // Item ID of HM01, with the other HMs following; 0 if unknown, so HMs aren't read
+}
+
+// vanillaProfile decodes saves from the original games
+var vanillaProfile = &profile{
+	format:        FormatVanilla,
+	speciesMask:   0xFFFF,
+	species:       gen3ToNationalDex,
+	maxNationalID: 251,
+	items:         gen3ItemToShowdown,
+	hmItemBase:    339,
+}
+
+// expansion18Profile decodes pokeemerald-expansion 1.8+ saves, which number species nationally up
+// to Gen 8 and move the security key to 0x44 after taking the Pokedex flags out of SaveBlock2
+var expansion18Profile = &profile{
+	format:        FormatExpansion18,
+	speciesMask:   0x7FF,
+	species:       expansionToNationalDex,
+	maxNationalID: 0x7FF,
+	items:         expansionItemToShowdown,
+	rawItems:      true,
+	packedGrowth:  true,
+	ribbonAbility: true,
+	keyOffset:     0x44,
+	movedPokedex:  true,
+}
+
+// expansion17Profile decodes saves from pokeemerald-expansion before 1.8, which numbers species
+// and items as later versions do but keeps the whole Growth species field and the ball in Misc
+var expansion17Profile = &profile{
+	format:        FormatExpansion17,
+	speciesMask:   0xFFFF,
+	species:       expansionToNationalDex,
+	maxNationalID: 0x7FF,
+	items:         expansionItemToShowdown,
+	rawItems:      true,
+	ribbonAbility: true,
+	keyOffset:     0x44,
+	movedPokedex:  true,
+}
+
+// cfruProfile decodes Complete FireRed Upgrade saves, which keep FireRed's Pokemon records and
+// item IDs but add species numbered by the Dynamic Pokemon Expansion
+// Items CFRU adds past FireRed's aren't mapped, so they decode as no item
+var cfruProfile = &profile{
+	format:        FormatCFRU,
+	speciesMask:   0xFFFF,
+	species:       cfruToNationalDex,
+	maxNationalID: 251,
+	items:         gen3ItemToShowdown,
+	hmItemBase:    339,
+}
+
+// profiles maps each format to its profile
+var profiles = map[Format]*profile{
+	FormatVanilla:     vanillaProfile,
+	FormatExpansion18: expansion18Profile,
+	FormatExpansion17: expansion17Profile,
+	FormatCFRU:        cfruProfile,
+}
+
+// maxVanillaSpecies is the last species ID of the original games (Chimecho)
+const maxVanillaSpecies = 411
+
+// speciesNum converts a species ID to a National Dex number, or 0 if it isn't a known species
+func (p *profile) speciesNum(rawSpeciesID int) int {
+	id := rawSpeciesID & p.speciesMask
+	if num, ok := p.species[id]; ok {
+		return num
+	}
+	if id <= p.maxNationalID {
+		return id
+	}
+	return 0
+}
+
+// itemNum converts an item ID to a Showdown item number, or 0 if it has none
+func (p *profile) itemNum(itemID int) int {
+	if num, ok := p.items[itemID]; ok {
+		return num
+	}
+	if p.rawItems {
+		return itemID
+	}
+	return 0
+}
+
+// detectProfile works out a save's format from its Pokemon
+// Ruby/Sapphire have no hack engine in use, so only Emerald and FireRed/LeafGreen saves are
+// checked. Each Pokemon with a valid checksum votes: species IDs 252-276 or above 411 are never
+// vanilla. pokeemerald-expansion 1.8+ keeps the ball in Growth where vanilla has padding, so a
+// ball there votes 1.8 and a ball only in the vanilla origins bits votes vanilla. On FireRed and
+// LeafGreen a CFRU species ID past the Egg and Unown letters votes CFRU.
+func detectProfile(l *layout, block1, pc []byte) *profile {
+	if l != emeraldLayout && l != fireRedLeafGreenLayout {
+		return vanillaProfile
+	}
+
+	records := [][]byte{}
+	if block1 != nil {
+		partyCount := int(block1[l.partyOffset])
+		for i := 0; i < partyCount && i < 6; i++ {
+			offset := l.partyOffset + 4 + i*100
+			records = append(records, block1[offset:offset+80])
+		}
+	}
+	for offset := pcHeaderSize; offset+80 <= len(pc) && offset < pcHeaderSize+pcBoxes*pokemonPerBox*80; offset += 80 {
+		records = append(records, pc[offset:offset+80])
+	}
+
+	if l == fireRedLeafGreenLayout {
+		for _, record := range records {
+			decrypted, pos, ok := decryptPokemon(record)
+			if !ok {
+				continue
+			}
+			species := int(binary.LittleEndian.Uint16(decrypted[pos[0] : pos[0]+2]))
+			if species >= cfruFirstNational && species <= cfruLastNational {
+				return cfruProfile
+			}
+		}
+		return vanillaProfile
+	}
+
+	expansion18, expansion17, vanilla := 0, 0, 0
+	for _, record := range records {
+		decrypted, pos, ok := decryptPokemon(record)
+		if !ok {
+			continue
+		}
+		growth, misc := decrypted[pos[0]:pos[0]+12], decrypted[pos[3]:pos[3]+12]
+		species := int(binary.LittleEndian.Uint16(growth[0:2]))
+		switch {
+		case species == 0:
+		case binary.LittleEndian.Uint16(growth[10:12])&0x3F != 0:
+			expansion18++
+		case species > maxVanillaSpecies || (species > 251 && species < 277):
+			expansion17++
+		case binary.LittleEndian.Uint16(misc[2:4])>>11&0xF != 0:
+			vanilla++
+		}
+	}
+
+	switch {
+	case vanilla > expansion18 && vanilla > expansion17:
+		return vanillaProfile
+	case expansion17 > expansion18:
+		return expansion17Profile
+	}
+	return expansion18Profile
+}
+
+// decryptPokemon decrypts the 48-byte data block of a Pokemon record
+// Returns the decrypted block, the position of each substructure (Growth, Attacks, EVs, Misc)
+// and whether the record is in use with a matching checksum
+func decryptPokemon(data []byte) ([]byte, [4]int, bool) {
+	personality := binary.LittleEndian.Uint32(data[0:4])
+	otID := binary.LittleEndian.Uint32(data[4:8])
+
+	// XOR each 4-byte word with the personality value and OT ID
+	encryptionKey := personality ^ otID
+	decryptedData := make([]byte, 48)
+	for i := 0; i < 48; i += 4 {
+		word := binary.LittleEndian.Uint32(data[32+i:36+i]) ^ encryptionKey
+		binary.LittleEndian.PutUint32(decryptedData[i:i+4], word)
+	}
+
+	// The substructures are shuffled by personality % 24
+	var positions [4]int
+	for pos, typ := range substructOrder[personality%24] {
+		positions[typ] = pos * 12
+	}
+
+	var sum uint16
+	for i := 0; i < 48; i += 2 {
+		sum += binary.LittleEndian.Uint16(decryptedData[i : i+2])
+	}
+	valid := personality != 0 && sum == binary.LittleEndian.Uint16(data[28:30])
+
+	return decryptedData, positions, valid
+}
+
+// securityKey returns the key a save slot's bag quantities and money are XORed with, or 0
+func securityKey(data []byte, slotBase int, l *layout, p *profile) uint32 {
+	offset := l.keyOffset
+	if p.keyOffset != 0 {
+		offset = p.keyOffset
+	}
+	section0 := sectionOffset(data, slotBase, 0)
+	if offset == -1 || section0 == -1 {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(data[section0+offset : section0+offset+4])
+}
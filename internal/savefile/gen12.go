@@ -0,0 +1,739 @@
+package savefile
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Gen 1 and Gen 2 game families
+const (
+	GameRedBlueYellow Game = "redblueyellow"
+	GameGoldSilver    Game = "goldsilver"
+	GameCrystal       Game = "crystal"
+)
+
+// Gen 1 and Gen 2 save constants
+// The save is 32 KB of SRAM in four 8 KB banks: bank 1 holds the main data with a single
+// checksum, banks 2 and 3 hold the PC boxes. Numbers are big-endian.
+const (
+	retroSaveSize   = 0x8000
+	retroBankSize   = 0x2000
+	retroMaxSize    = 0x10000 // Emulators may append a real-time clock footer
+	retroNameSize   = 11      // Names are 10 characters and a terminator
+	retroTerminator = 0x50
+	retroEgg        = 0xFD // Species list entry of an egg
+	retroBoxSize    = 20   // Pokemon per box
+)
+
+// retroPokemon holds the offsets of a Gen 1 or Gen 2 Pokemon structure; -1 where the
+// generation has no such field
+type retroPokemon struct {
+	boxSize   int
+	partySize int
+
+	species    int
+	item       int
+	moves      int
+	otID       int
+	exp        int // 3 bytes
+	statExp    int // HP, Attack, Defense, Speed, Special: 2 bytes each
+	dvs        int // Attack, Defense, Speed, Special: 4 bits each
+	pp         int // Bits 0-5 current PP, bits 6-7 PP Ups
+	friendship int
+	pokerus    int
+	caught     int // Crystal's met level and location
+	boxLevel   int
+
+	partyLevel   int
+	status       int
+	currentHP    int
+	stats        int  // Max HP, then Attack, Defense, Speed and Special
+	splitSpecial bool // Special is split into Sp. Atk and Sp. Def
+}
+
+// gen1Pokemon is the 33-byte box and 44-byte party structure of Red, Blue and Yellow
+var gen1Pokemon = &retroPokemon{
+	boxSize:   33,
+	partySize: 44,
+
+	species:    0x00,
+	item:       -1, // Catch rate in Gen 1; Gen 2 reads it as the held item
+	moves:      0x08,
+	otID:       0x0C,
+	exp:        0x0E,
+	statExp:    0x11,
+	dvs:        0x1B,
+	pp:         0x1D,
+	friendship: -1,
+	pokerus:    -1,
+	caught:     -1,
+	boxLevel:   0x03,
+
+	partyLevel: 0x21,
+	status:     0x04,
+	currentHP:  0x01,
+	stats:      0x22,
+}
+
+// gen2Pokemon is the 32-byte box and 48-byte party structure of Gold, Silver and Crystal
+var gen2Pokemon = &retroPokemon{
+	boxSize:   32,
+	partySize: 48,
+
+	species:    0x00,
+	item:       0x01,
+	moves:      0x02,
+	otID:       0x06,
+	exp:        0x08,
+	statExp:    0x0B,
+	dvs:        0x15,
+	pp:         0x17,
+	friendship: 0x1B,
+	pokerus:    0x1C,
+	caught:     0x1D,
+	boxLevel:   0x1F,
+
+	partyLevel:   0x1F,
+	status:       0x20,
+	currentHP:    0x22,
+	stats:        0x24,
+	splitSpecial: true,
+}
+
+// retroLayout holds the offsets that differ between the Gen 1 and Gen 2 games
+// Pockets are lists: a count, then item ID and quantity pairs, then 0xFF.
+type retroLayout struct {
+	game     Game
+	pokemon  *retroPokemon
+	species  map[int]int // Internal species IDs to National Dex numbers; nil if they're the same
+	itemNums map[int]int // Item IDs to Showdown item numbers
+
+	checksumStart  int // First byte of the checksummed data
+	checksumEnd    int // Last byte of the checksummed data
+	checksumOffset int
+	checksum16     bool // Gen 2 stores the 16-bit sum; Gen 1 the complement of the 8-bit sum
+
+	nameOffset     int
+	idOffset       int
+	genderOffset   int // -1 if the player is always male
+	moneyOffset    int
+	moneyBCD       bool // Gen 1 stores money and coins as binary-coded decimal
+	coinsOffset    int
+	playTimeOffset int
+	hours16        bool // Gen 2 counts hours in 2 bytes
+
+	items   pocket
+	balls   pocket // Gen 1 keeps balls in the items pocket; slots is 0
+	pcItems pocket
+
+	badgesOffset int
+	badges       []string
+	kantoBadges  bool // Gen 2 keeps the Kanto badges in the byte after the Johto badges
+	tmsOffset    int  // Gen 2 TM pocket: a count for each TM, then each HM; -1 in Gen 1
+	hmItemBase   int  // Gen 1 item ID of HM01; Gen 2 HMs are in the TM pocket
+	hmMoves      []string
+
+	partyOffset      int
+	currentBoxIndex  int
+	currentBoxOffset int // Working copy of the current box; its bank copy is stale
+	boxes            int
+	boxesPerBank     int
+	boxStride        int
+
+	pokedexOwned int
+	pokedexSeen  int
+	pokedexBytes int
+}
+
+// redBlueYellowLayout is the save layout of Red, Blue and Yellow
+var redBlueYellowLayout = &retroLayout{
+	game:     GameRedBlueYellow,
+	pokemon:  gen1Pokemon,
+	species:  gen1ToNationalDex,
+	itemNums: gen1ItemToShowdown,
+
+	checksumStart:  0x2598,
+	checksumEnd:    0x3522,
+	checksumOffset: 0x3523,
+
+	nameOffset:     0x2598,
+	idOffset:       0x2605,
+	genderOffset:   -1,
+	moneyOffset:    0x25F3,
+	moneyBCD:       true,
+	coinsOffset:    0x2850,
+	playTimeOffset: 0x2CED,
+
+	items:   pocket{0x25C9, 20},
+	pcItems: pocket{0x27E6, 50},
+
+	badgesOffset: 0x2602,
+	badges:       kantoBadges,
+	tmsOffset:    -1,
+	hmItemBase:   0xC4,
+	hmMoves:      []string{"Cut", "Fly", "Surf", "Strength", "Flash"},
+
+	partyOffset:      0x2F2C,
+	currentBoxIndex:  0x284C,
+	currentBoxOffset: 0x30C0,
+	boxes:            12,
+	boxesPerBank:     6,
+	boxStride:        0x462,
+
+	pokedexOwned: 0x25A3,
+	pokedexSeen:  0x25B6,
+	pokedexBytes: 19,
+}
+
+// gen2HMMoves are taught by HM01-HM07 in Gold, Silver and Crystal
+var gen2HMMoves = []string{"Cut", "Fly", "Surf", "Strength", "Flash", "Whirlpool", "Waterfall"}
+
+// goldSilverLayout is the save layout of Gold and Silver
+var goldSilverLayout = &retroLayout{
+	game:     GameGoldSilver,
+	pokemon:  gen2Pokemon,
+	itemNums: gen2ItemToShowdown,
+
+	checksumStart:  0x2009,
+	checksumEnd:    0x2D68,
+	checksumOffset: 0x2D69,
+	checksum16:     true,
+
+	nameOffset:     0x200B,
+	idOffset:       0x2009,
+	genderOffset:   -1,
+	moneyOffset:    0x23DB,
+	coinsOffset:    0x23E2,
+	playTimeOffset: 0x2053,
+	hours16:        true,
+
+	items:   pocket{0x241F, 20},
+	balls:   pocket{0x2464, 12},
+	pcItems: pocket{0x247E, 50},
+
+	badgesOffset: 0x23E4,
+	badges:       johtoBadges,
+	kantoBadges:  true,
+	tmsOffset:    0x23E6,
+	hmMoves:      gen2HMMoves,
+
+	partyOffset:      0x288A,
+	currentBoxIndex:  0x2724,
+	currentBoxOffset: 0x2D6C,
+	boxes:            14,
+	boxesPerBank:     7,
+	boxStride:        0x450,
+
+	pokedexOwned: 0x2A4C,
+	pokedexSeen:  0x2A6C,
+	pokedexBytes: 32,
+}
+
+// crystalLayout is the save layout of Crystal
+var crystalLayout = &retroLayout{
+	game:     GameCrystal,
+	pokemon:  gen2Pokemon,
+	itemNums: gen2ItemToShowdown,
+
+	checksumStart:  0x2009,
+	checksumEnd:    0x2B82,
+	checksumOffset: 0x2D0D,
+	checksum16:     true,
+
+	nameOffset:     0x200B,
+	idOffset:       0x2009,
+	genderOffset:   0x3E3D,
+	moneyOffset:    0x23DC,
+	coinsOffset:    0x23E3,
+	playTimeOffset: 0x2052,
+	hours16:        true,
+
+	items:   pocket{0x2420, 20},
+	balls:   pocket{0x2465, 12},
+	pcItems: pocket{0x247F, 50},
+
+	badgesOffset: 0x23E5,
+	badges:       johtoBadges,
+	kantoBadges:  true,
+	tmsOffset:    0x23E7,
+	hmMoves:      gen2HMMoves,
+
+	partyOffset:      0x2865,
+	currentBoxIndex:  0x2700,
+	currentBoxOffset: 0x2D10,
+	boxes:            14,
+	boxesPerBank:     7,
+	boxStride:        0x450,
+
+	pokedexOwned: 0x2A27,
+	pokedexSeen:  0x2A47,
+	pokedexBytes: 32,
+}
+
+// retroLayouts lists every Gen 1 and Gen 2 layout, Gen 2 first since Gen 1's 8-bit checksum
+// is the likeliest to match by chance
+var retroLayouts = []*retroLayout{goldSilverLayout, crystalLayout, redBlueYellowLayout}
+
+// detectRetroLayout works out which Gen 1 or Gen 2 game a save is from, or returns nil if the
+// data isn't a Gen 1 or Gen 2 save
+// Each game keeps its checksum in a different place, so the first valid checksum picks the
+// game. A save with no valid checksum is matched by a party list that ends where it should.
+func detectRetroLayout(data []byte) *retroLayout {
+	if len(data) < retroSaveSize || len(data) >= retroMaxSize {
+		return nil
+	}
+	for _, l := range retroLayouts {
+		if l.checksumValid(data) {
+			return l
+		}
+	}
+	for _, l := range retroLayouts {
+		count := int(data[l.partyOffset])
+		if count >= 1 && count <= 6 && data[l.partyOffset+1+count] == 0xFF {
+			return l
+		}
+	}
+	return nil
+}
+
+// IsRetroSave returns true if the data is a Red, Blue, Yellow, Gold, Silver or Crystal save
+func IsRetroSave(data []byte) bool {
+	return detectRetroLayout(data) != nil
+}
+
+// checksumValid checks the main data checksum of a save against the layout
+func (l *retroLayout) checksumValid(data []byte) bool {
+	var sum uint16
+	for _, b := range data[l.checksumStart : l.checksumEnd+1] {
+		sum += uint16(b)
+	}
+	if l.checksum16 {
+		return binary.LittleEndian.Uint16(data[l.checksumOffset:l.checksumOffset+2]) == sum
+	}
+	return data[l.checksumOffset] == ^byte(sum)
+}
+
+// checkRetroIntegrity reports the main data checksum as slot A
+// Gen 2's backup copy of the main data isn't checked or parsed.
+func checkRetroIntegrity(data []byte, l *retroLayout) *Integrity {
+	report := SlotReport{
+		Slot:            slotNames[0],
+		BadSections:     []int{},
+		MissingSections: []int{},
+		Problems:        []string{},
+	}
+	if !l.checksumValid(data) {
+		report.BadSections = append(report.BadSections, 0)
+		report.Problems = append(report.Problems, "main data checksum mismatch")
+	}
+	report.Valid = len(report.Problems) == 0
+	return &Integrity{
+		Slots:    []SlotReport{report},
+		UsedSlot: report.Slot,
+		Valid:    report.Valid,
+	}
+}
+
+// ValidateRetroSave checks a Gen 1 or Gen 2 save's main data checksum
+func ValidateRetroSave(data []byte) error {
+	l := detectRetroLayout(data)
+	if l == nil {
+		return errors.New("not a Gen 1 or Gen 2 save file")
+	}
+	if !l.checksumValid(data) {
+		return fmt.Errorf("%s main data checksum mismatch", l.game)
+	}
+	return nil
+}
+
+// ParseRetroSave parses a Red, Blue, Yellow, Gold, Silver or Crystal save
+// DVs are reported as IVs and stat experience as EVs; Special fills both Sp. Atk and Sp. Def.
+func ParseRetroSave(data []byte) (*ParseResult, error) {
+	l := detectRetroLayout(data)
+	if l == nil {
+		return nil, errors.New("not a Gen 1 or Gen 2 save file")
+	}
+
+	result := &ParseResult{
+		Game:      l.game,
+		Format:    FormatVanilla,
+		Party:     []PartyPokemon{},
+		Boxes:     make([][]BoxPokemon, l.boxes),
+		Bag:       parseRetroBag(data, l),
+		Progress:  parseRetroProgress(data, l),
+		Trainer:   parseRetroTrainer(data, l),
+		Integrity: checkRetroIntegrity(data, l),
+		Pokedex: &Pokedex{
+			Owned: dexNumbers(data[l.pokedexOwned : l.pokedexOwned+l.pokedexBytes]),
+			Seen:  dexNumbers(data[l.pokedexSeen : l.pokedexSeen+l.pokedexBytes]),
+		},
+	}
+
+	for _, entry := range parseRetroList(data, l.partyOffset, 6, l.pokemon.partySize) {
+		if pokemon, ok := parseRetroPartyPokemon(entry, l); ok {
+			result.Party = append(result.Party, pokemon)
+		}
+	}
+
+	currentBox := int(data[l.currentBoxIndex] & 0x7F)
+	for boxIdx := range result.Boxes {
+		result.Boxes[boxIdx] = []BoxPokemon{}
+		offset := retroBankSize*(2+boxIdx/l.boxesPerBank) + (boxIdx%l.boxesPerBank)*l.boxStride
+		if boxIdx == currentBox {
+			offset = l.currentBoxOffset
+		}
+		for _, entry := range parseRetroList(data, offset, retroBoxSize, l.pokemon.boxSize) {
+			if pokemon, ok := parseRetroBoxPokemon(entry, l); ok {
+				result.Boxes[boxIdx] = append(result.Boxes[boxIdx], pokemon)
+			}
+		}
+	}
+
+	if len(result.Party) == 0 {
+		return nil, errors.New("no party Pokemon found")
+	}
+
+	return result, nil
+}
+
+// retroEntry is one Pokemon of a party or box list
+type retroEntry struct {
+	data     []byte
+	otName   []byte
+	nickname []byte
+}
+
+// parseRetroList reads a party or box list: a count, the species list with a terminator,
+// the Pokemon, then the OT names and nicknames. Eggs are skipped.
+func parseRetroList(data []byte, offset, capacity, size int) []retroEntry {
+	count := int(data[offset])
+	if count > capacity {
+		return nil // An uninitialized box
+	}
+	pokemonOffset := offset + 1 + capacity + 1
+	otOffset := pokemonOffset + capacity*size
+	nicknameOffset := otOffset + capacity*retroNameSize
+
+	entries := make([]retroEntry, 0, count)
+	for i := 0; i < count; i++ {
+		if data[offset+1+i] == retroEgg {
+			continue
+		}
+		entries = append(entries, retroEntry{
+			data:     data[pokemonOffset+i*size : pokemonOffset+(i+1)*size],
+			otName:   data[otOffset+i*retroNameSize : otOffset+(i+1)*retroNameSize],
+			nickname: data[nicknameOffset+i*retroNameSize : nicknameOffset+(i+1)*retroNameSize],
+		})
+	}
+	return entries
+}
+
+// parseRetroBoxPokemon parses a Gen 1 or Gen 2 Pokemon's box data
+func parseRetroBoxPokemon(entry retroEntry, l *retroLayout) (BoxPokemon, bool) {
+	d, f := entry.data, l.pokemon
+	speciesNum := int(d[f.species])
+	if l.species != nil {
+		speciesNum = l.species[speciesNum]
+	}
+	if speciesNum == 0 || speciesNum > 251 {
+		return BoxPokemon{}, false
+	}
+
+	moveNums := make([]int, 0, 4)
+	for i := 0; i < 4; i++ {
+		if moveID := int(d[f.moves+i]); moveID > 0 {
+			moveNums = append(moveNums, moveID)
+		}
+	}
+
+	dvs := binary.BigEndian.Uint16(d[f.dvs : f.dvs+2])
+	otID := uint32(binary.BigEndian.Uint16(d[f.otID : f.otID+2]))
+	statExp := func(i int) int {
+		return int(binary.BigEndian.Uint16(d[f.statExp+i*2 : f.statExp+i*2+2]))
+	}
+
+	pokemon := BoxPokemon{
+		Personality: retroPersonality(dvs),
+		OTID:        otID,
+		Nickname:    decodeRetroString(entry.nickname),
+		Level:       int(d[f.boxLevel]),
+		SpeciesNum:  speciesNum,
+		MoveNums:    moveNums,
+		IVs:         dvsToIVs(dvs),
+		EVs: PokemonStats{
+			HP:      statExp(0),
+			Attack:  statExp(1),
+			Defense: statExp(2),
+			Speed:   statExp(3),
+			SpAtk:   statExp(4),
+			SpDef:   statExp(4),
+		},
+		Experience: uint32(d[f.exp])<<16 | uint32(d[f.exp+1])<<8 | uint32(d[f.exp+2]),
+		Origin: Origin{
+			OTName:   decodeRetroString(entry.otName),
+			OTGender: "male",
+		},
+	}
+	if f.item != -1 {
+		pokemon.ItemNum = l.itemNums[int(d[f.item])]
+	}
+	if f.friendship != -1 {
+		pokemon.Friendship = int(d[f.friendship])
+	}
+	if f.pokerus != -1 {
+		pokemon.Pokerus = parsePokerus(d[f.pokerus])
+	}
+	if f.caught != -1 {
+		// Crystal: met level in bits 0-5 of the first byte, met location and OT gender in the second
+		pokemon.Origin.MetLevel = int(d[f.caught] & 0x3F)
+		pokemon.Origin.MetLocation = int(d[f.caught+1] & 0x7F)
+		if d[f.caught+1]&0x80 != 0 {
+			pokemon.Origin.OTGender = "female"
+		}
+		// Shininess and Unown's letter come from the DVs from Gen 2 on
+		pokemon.Traits = dvTraits(dvs, speciesNum)
+	}
+
+	return pokemon, true
+}
+
+// parseRetroPartyPokemon parses a Gen 1 or Gen 2 party Pokemon: the box data plus the battle stats
+func parseRetroPartyPokemon(entry retroEntry, l *retroLayout) (PartyPokemon, bool) {
+	box, ok := parseRetroBoxPokemon(entry, l)
+	if !ok {
+		return PartyPokemon{}, false
+	}
+	d, f := entry.data, l.pokemon
+
+	pp := make([]int, 0, len(box.MoveNums))
+	ppUps := make([]int, 0, len(box.MoveNums))
+	for i := 0; i < 4; i++ {
+		if d[f.moves+i] > 0 {
+			pp = append(pp, int(d[f.pp+i]&0x3F))
+			ppUps = append(ppUps, int(d[f.pp+i]>>6))
+		}
+	}
+
+	stat := func(i int) int {
+		return int(binary.BigEndian.Uint16(d[f.stats+i*2 : f.stats+i*2+2]))
+	}
+	stats := PokemonStats{
+		HP:      stat(0),
+		Attack:  stat(1),
+		Defense: stat(2),
+		Speed:   stat(3),
+		SpAtk:   stat(4),
+		SpDef:   stat(4),
+	}
+	if f.splitSpecial {
+		stats.SpDef = stat(5)
+	}
+
+	return PartyPokemon{
+		Personality: box.Personality,
+		OTID:        box.OTID,
+		Nickname:    box.Nickname,
+		Level:       int(d[f.partyLevel]),
+		SpeciesNum:  box.SpeciesNum,
+		ItemNum:     box.ItemNum,
+		MoveNums:    box.MoveNums,
+		Stats:       stats,
+		IVs:         box.IVs,
+		EVs:         box.EVs,
+		CurrentHP:   int(binary.BigEndian.Uint16(d[f.currentHP : f.currentHP+2])),
+		Status:      parseStatus(uint32(d[f.status])),
+		PP:          pp,
+		PPUps:       ppUps,
+		Experience:  box.Experience,
+		Friendship:  box.Friendship,
+		Origin:      box.Origin,
+		Pokerus:     box.Pokerus,
+		Traits:      box.Traits,
+	}, true
+}
+
+// dvsToIVs spreads the DVs over the IV fields
+// The HP DV is made of the lowest bit of each of the others.
+func dvsToIVs(dvs uint16) PokemonStats {
+	attack, defense, speed, special := int(dvs>>12), int(dvs>>8&0xF), int(dvs>>4&0xF), int(dvs&0xF)
+	return PokemonStats{
+		HP:      (attack&1)<<3 | (defense&1)<<2 | (speed&1)<<1 | special&1,
+		Attack:  attack,
+		Defense: defense,
+		Speed:   speed,
+		SpAtk:   special,
+		SpDef:   special,
+	}
+}
+
+// retroPersonality stands in for the personality value Gen 1 and Gen 2 Pokemon don't have
+// The DVs identify the Pokemon in the high half; the Attack DV in the low byte makes
+// GenderFromPersonality give the Gen 2 gender, which is female when the Attack DV is at or
+// below the species' threshold.
+func retroPersonality(dvs uint16) uint32 {
+	return uint32(dvs)<<16 | uint32(dvs>>12)<<4
+}
+
+// dvTraits derives Gen 2 shininess and Unown's letter from the DVs
+// A Pokemon is shiny with Defense, Speed and Special DVs of 10 and an Attack DV of 2, 3, 6, 7,
+// 10, 11, 14 or 15. Unown's letter comes from the middle two bits of each DV.
+func dvTraits(dvs uint16, speciesNum int) Traits {
+	ivs := dvsToIVs(dvs)
+	traits := Traits{
+		Shiny: ivs.Defense == 10 && ivs.Speed == 10 && ivs.SpAtk == 10 && ivs.Attack&2 != 0,
+	}
+	if speciesNum == speciesUnown {
+		letter := (ivs.Attack&6)<<5 | (ivs.Defense&6)<<3 | (ivs.Speed&6)<<1 | (ivs.SpAtk&6)>>1
+		traits.UnownLetter = string(unownLetters[letter/10])
+	}
+	return traits
+}
+
+// parseRetroBag parses the item pockets
+// Gen 2 key items and TMs have no Showdown equivalent, so those pockets stay empty.
+func parseRetroBag(data []byte, l *retroLayout) *BagPockets {
+	parsePocket := func(pk pocket) []BagItem {
+		items := []BagItem{}
+		count := int(data[pk.offset])
+		for i := 0; i < count && i < pk.slots; i++ {
+			slot := pk.offset + 1 + i*2
+			itemNum := l.itemNums[int(data[slot])]
+			quantity := int(data[slot+1])
+			if itemNum == 0 || quantity == 0 {
+				continue
+			}
+			items = append(items, BagItem{ItemNum: itemNum, Quantity: quantity})
+		}
+		return items
+	}
+
+	return &BagPockets{
+		PCItems:   parsePocket(l.pcItems),
+		Items:     parsePocket(l.items),
+		KeyItems:  []BagItem{},
+		PokeBalls: parsePocket(l.balls),
+		TMsHMs:    []BagItem{},
+		Berries:   []BagItem{},
+	}
+}
+
+// parseRetroTrainer reads the trainer card, money and coins
+// Gen 1 and Gen 2 have no secret ID.
+func parseRetroTrainer(data []byte, l *retroLayout) *Trainer {
+	trainer := &Trainer{
+		Name:   decodeRetroString(data[l.nameOffset : l.nameOffset+retroNameSize]),
+		Gender: "male",
+		ID:     binary.BigEndian.Uint16(data[l.idOffset : l.idOffset+2]),
+	}
+	if l.genderOffset != -1 && data[l.genderOffset] == 1 {
+		trainer.Gender = "female"
+	}
+
+	money := data[l.moneyOffset : l.moneyOffset+3]
+	coins := data[l.coinsOffset : l.coinsOffset+2]
+	if l.moneyBCD {
+		trainer.Money = uint32(decodeBCD(money))
+		trainer.Coins = uint16(decodeBCD(coins))
+	} else {
+		trainer.Money = uint32(money[0])<<16 | uint32(money[1])<<8 | uint32(money[2])
+		trainer.Coins = binary.BigEndian.Uint16(coins)
+	}
+
+	t := data[l.playTimeOffset:]
+	if l.hours16 {
+		trainer.PlayTime = PlayTime{Hours: int(binary.BigEndian.Uint16(t[0:2])), Minutes: int(t[2]), Seconds: int(t[3])}
+	} else {
+		// Gen 1: hours, a flag set when the clock maxes out, minutes, seconds
+		trainer.PlayTime = PlayTime{Hours: int(t[0]), Minutes: int(t[2]), Seconds: int(t[3])}
+	}
+
+	return trainer
+}
+
+// decodeBCD decodes big-endian binary-coded decimal, two digits per byte
+func decodeBCD(data []byte) int {
+	n := 0
+	for _, b := range data {
+		n = n*100 + int(b>>4)*10 + int(b&0xF)
+	}
+	return n
+}
+
+// parseRetroProgress reads the badges and the HMs obtained
+// Gen 1 and Gen 2 trainer and story flags aren't mapped, so those lists stay empty
+func parseRetroProgress(data []byte, l *retroLayout) *Progress {
+	progress := &Progress{
+		Badges:           []string{},
+		DefeatedTrainers: []int{},
+		StoryFlags:       []string{},
+		HMs:              []string{},
+	}
+
+	badgeNames := [][]string{l.badges}
+	if l.kantoBadges {
+		badgeNames = append(badgeNames, kantoBadges)
+	}
+	for i, names := range badgeNames {
+		for bit, name := range names {
+			if data[l.badgesOffset+i]&(1<<bit) != 0 {
+				progress.Badges = append(progress.Badges, name)
+			}
+		}
+	}
+	progress.BadgeCount = len(progress.Badges)
+
+	// HMs can't be tossed, so one in the bag or PC means it was obtained
+	owned := make([]bool, len(l.hmMoves))
+	if l.tmsOffset != -1 {
+		for i := range owned {
+			owned[i] = data[l.tmsOffset+50+i] > 0 // After the counts of TM01-TM50
+		}
+	} else {
+		for _, pk := range []pocket{l.items, l.pcItems} {
+			for i := 0; i < int(data[pk.offset]) && i < pk.slots; i++ {
+				item := int(data[pk.offset+1+i*2])
+				if item >= l.hmItemBase && item < l.hmItemBase+len(l.hmMoves) {
+					owned[item-l.hmItemBase] = true
+				}
+			}
+		}
+	}
+	for i, move := range l.hmMoves {
+		if owned[i] {
+			progress.HMs = append(progress.HMs, move)
+		}
+	}
+
+	return progress
+}
+
+// retroCharTable maps the Gen 1 and Gen 2 characters other than letters and digits to runes
+var retroCharTable = map[byte]rune{
+	0x7F: ' ', 0x9A: '(', 0x9B: ')', 0x9C: ':', 0x9D: ';', 0x9E: '[', 0x9F: ']',
+	0xBA: 'é', 0xE0: '\'', 0xE3: '-', 0xE6: '?', 0xE7: '!', 0xE8: '.',
+	0xEF: '♂', 0xF0: '¥', 0xF1: '×', 0xF3: '/', 0xF4: ',', 0xF5: '♀',
+}
+
+// decodeRetroString decodes a Gen 1 or Gen 2 string up to its terminator
+// Letters are in order from 0x80 (upper case) and 0xA0 (lower case), digits from 0xF6
+func decodeRetroString(data []byte) string {
+	result := make([]rune, 0, len(data))
+	for _, b := range data {
+		switch {
+		case b == retroTerminator:
+			return string(result)
+		case b >= 0x80 && b <= 0x99:
+			result = append(result, rune('A'+b-0x80))
+		case b >= 0xA0 && b <= 0xB9:
+			result = append(result, rune('a'+b-0xA0))
+		case b >= 0xF6:
+			result = append(result, rune('0'+b-0xF6))
+		default:
+			if char, ok := retroCharTable[b]; ok {
+				result = append(result, char)
+			}
+		}
+	}
+	return string(result)
+}
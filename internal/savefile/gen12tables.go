@@ -0,0 +1,218 @@
+package savefile
+
+// gen1ToNationalDex maps the internal species IDs of Red, Blue and Yellow to National Dex numbers
+// IDs missing from the table are MissingNo. slots
+var gen1ToNationalDex = map[int]int{
+	0x01: 112, 0x02: 115, 0x03: 32, 0x04: 35, 0x05: 21, 0x06: 100,
+	0x07: 34, 0x08: 80, 0x09: 2, 0x0A: 103, 0x0B: 108, 0x0C: 102,
+	0x0D: 88, 0x0E: 94, 0x0F: 29, 0x10: 31, 0x11: 104, 0x12: 111,
+	0x13: 131, 0x14: 59, 0x15: 151, 0x16: 130, 0x17: 90, 0x18: 72,
+	0x19: 92, 0x1A: 123, 0x1B: 120, 0x1C: 9, 0x1D: 127, 0x1E: 114,
+	0x21: 58, 0x22: 95, 0x23: 22, 0x24: 16, 0x25: 79, 0x26: 64,
+	0x27: 75, 0x28: 113, 0x29: 67, 0x2A: 122, 0x2B: 106, 0x2C: 107,
+	0x2D: 24, 0x2E: 47, 0x2F: 54, 0x30: 96, 0x31: 76, 0x33: 126,
+	0x35: 125, 0x36: 82, 0x37: 109, 0x39: 56, 0x3A: 86, 0x3B: 50,
+	0x3C: 128, 0x40: 83, 0x41: 48, 0x42: 149, 0x46: 84, 0x47: 60,
+	0x48: 124, 0x49: 146, 0x4A: 144, 0x4B: 145, 0x4C: 132, 0x4D: 52,
+	0x4E: 98, 0x52: 37, 0x53: 38, 0x54: 25, 0x55: 26, 0x58: 147,
+	0x59: 148, 0x5A: 140, 0x5B: 141, 0x5C: 116, 0x5D: 117, 0x60: 27,
+	0x61: 28, 0x62: 138, 0x63: 139, 0x64: 39, 0x65: 40, 0x66: 133,
+	0x67: 136, 0x68: 135, 0x69: 134, 0x6A: 66, 0x6B: 41, 0x6C: 23,
+	0x6D: 46, 0x6E: 61, 0x6F: 62, 0x70: 13, 0x71: 14, 0x72: 15,
+	0x74: 85, 0x75: 57, 0x76: 51, 0x77: 49, 0x78: 87, 0x7B: 10,
+	0x7C: 11, 0x7D: 12, 0x7E: 68, 0x80: 55, 0x81: 97, 0x82: 42,
+	0x83: 150, 0x84: 143, 0x85: 129, 0x88: 89, 0x8A: 99, 0x8B: 91,
+	0x8D: 101, 0x8E: 36, 0x8F: 110, 0x90: 53, 0x91: 105, 0x93: 93,
+	0x94: 63, 0x95: 65, 0x96: 17, 0x97: 18, 0x98: 121, 0x99: 1,
+	0x9A: 3, 0x9B: 73, 0x9D: 118, 0x9E: 119, 0xA3: 77, 0xA4: 78,
+	0xA5: 19, 0xA6: 20, 0xA7: 33, 0xA8: 30, 0xA9: 74, 0xAA: 137,
+	0xAB: 142, 0xAD: 81, 0xB0: 4, 0xB1: 7, 0xB2: 5, 0xB3: 8,
+	0xB4: 6, 0xB9: 43, 0xBA: 44, 0xBB: 45, 0xBC: 69, 0xBD: 70,
+	0xBE: 71,
+}
+
+// gen1ItemToShowdown maps the item IDs of Red, Blue and Yellow to Showdown item numbers
+// Key items, badges, TMs and HMs have no Showdown equivalent and are left out
+var gen1ItemToShowdown = map[int]int{
+	1:  276,   // Master Ball
+	2:  521,   // Ultra Ball
+	3:  174,   // Great Ball
+	4:  345,   // Poke Ball
+	8:  425,   // Safari Ball
+	10: 295,   // Moon Stone
+	11: 18,    // Antidote
+	12: 19,    // Burn Heal
+	13: 20,    // Ice Heal
+	14: 10004, // Awakening
+	15: 10005, // Paralyze Heal
+	16: 23,    // Full Restore
+	17: 24,    // Max Potion
+	18: 25,    // Hyper Potion
+	19: 26,    // Super Potion
+	20: 10003, // Potion
+	29: 10029, // Escape Rope
+	30: 79,    // Repel
+	31: 314,   // Old Amber
+	32: 142,   // Fire Stone
+	33: 492,   // Thunder Stone
+	34: 529,   // Water Stone
+	35: 45,    // HP Up
+	36: 46,    // Protein
+	37: 47,    // Iron
+	38: 48,    // Carbos
+	39: 49,    // Calcium
+	40: 50,    // Rare Candy
+	41: 102,   // Dome Fossil
+	42: 195,   // Helix Fossil
+	46: 10024, // X Accuracy
+	47: 241,   // Leaf Stone
+	49: 10033, // Nugget
+	51: 10026, // Poké Doll
+	52: 10006, // Full Heal
+	53: 28,    // Revive
+	54: 10007, // Max Revive
+	55: 55,    // Guard Spec.
+	56: 10028, // Super Repel
+	57: 77,    // Max Repel
+	58: 56,    // Dire Hit
+	60: 30,    // Fresh Water
+	61: 10008, // Soda Pop
+	62: 10009, // Lemonade
+	65: 57,    // X Attack
+	66: 58,    // X Defense
+	67: 59,    // X Speed
+	68: 10025, // X Sp. Atk
+	79: 10022, // PP Up
+	80: 38,    // Ether
+	81: 39,    // Max Ether
+	82: 40,    // Elixir
+	83: 10012, // Max Elixir
+}
+
+// gen2ItemToShowdown maps the item IDs of Gold, Silver and Crystal to Showdown item numbers
+// Key items, Apricorns, TMs, HMs and mail have no Showdown equivalent and are left out
+var gen2ItemToShowdown = map[int]int{
+	1:   276,   // Master Ball
+	2:   521,   // Ultra Ball
+	3:   51,    // Bright Powder
+	4:   174,   // Great Ball
+	5:   345,   // Poke Ball
+	8:   295,   // Moon Stone
+	9:   18,    // Antidote
+	10:  19,    // Burn Heal
+	11:  20,    // Ice Heal
+	12:  10004, // Awakening
+	13:  10005, // Paralyze Heal
+	14:  23,    // Full Restore
+	15:  24,    // Max Potion
+	16:  25,    // Hyper Potion
+	17:  26,    // Super Potion
+	18:  10003, // Potion
+	19:  10029, // Escape Rope
+	20:  79,    // Repel
+	21:  10012, // Max Elixir
+	22:  142,   // Fire Stone
+	23:  492,   // Thunder Stone
+	24:  529,   // Water Stone
+	26:  45,    // HP Up
+	27:  46,    // Protein
+	28:  47,    // Iron
+	29:  48,    // Carbos
+	30:  261,   // Lucky Punch
+	31:  49,    // Calcium
+	32:  50,    // Rare Candy
+	33:  10024, // X Accuracy
+	34:  241,   // Leaf Stone
+	35:  10039, // Metal Powder
+	36:  10033, // Nugget
+	37:  10026, // Poké Doll
+	38:  10006, // Full Heal
+	39:  28,    // Revive
+	40:  10007, // Max Revive
+	41:  55,    // Guard Spec.
+	42:  10028, // Super Repel
+	43:  77,    // Max Repel
+	44:  56,    // Dire Hit
+	46:  30,    // Fresh Water
+	47:  10008, // Soda Pop
+	48:  10009, // Lemonade
+	49:  57,    // X Attack
+	51:  58,    // X Defense
+	52:  59,    // X Speed
+	53:  10025, // X Sp. Atk
+	57:  10035, // Exp. Share
+	60:  1030,  // Silver Leaf
+	62:  10022, // PP Up
+	63:  38,    // Ether
+	64:  39,    // Max Ether
+	65:  40,    // Elixir
+	72:  33,    // Moomoo Milk
+	73:  10036, // Quick Claw
+	74:  10052, // PSN Cure Berry
+	75:  1029,  // Gold Leaf
+	76:  456,   // Soft Sand
+	77:  436,   // Sharp Beak
+	78:  10053, // PRZ Cure Berry
+	79:  10054, // Burnt Berry
+	80:  10055, // Ice Berry
+	81:  343,   // Poison Barb
+	82:  236,   // King's Rock
+	83:  10056, // Bitter Berry
+	84:  10057, // Mint Berry
+	86:  10030, // Tiny Mushroom
+	87:  87,    // Big Mushroom
+	88:  447,   // Silver Powder
+	91:  200,   // Amulet Coin
+	94:  201,   // Cleanse Tag
+	95:  300,   // Mystic Water
+	96:  520,   // Twisted Spoon
+	98:  32,    // Black Belt
+	102: 35,    // Black Glasses
+	103: 10058, // Slowpoke Tail
+	104: 10059, // Pink Bow
+	105: 10040, // Stick
+	106: 205,   // Smoke Ball
+	107: 305,   // Never-Melt Ice
+	108: 273,   // Magnet
+	109: 10060, // Miracle Berry
+	110: 10031, // Pearl
+	111: 10032, // Big Pearl
+	112: 206,   // Everstone
+	113: 461,   // Spell Tag
+	114: 466,   // Rage Candy Bar
+	117: 10038, // Miracle Seed
+	118: 491,   // Thick Club
+	119: 150,   // Focus Band
+	121: 10010, // Energy Powder
+	122: 10011, // Energy Root
+	123: 36,    // Heal Powder
+	124: 37,    // Revival Herb
+	125: 187,   // Hard Stone
+	126: 208,   // Lucky Egg
+	131: 90,    // Stardust
+	132: 91,    // Star Piece
+	138: 61,    // Charcoal
+	139: 22,    // Berry Juice
+	140: 429,   // Scope Lens
+	143: 286,   // Metal Coat
+	144: 106,   // Dragon Fang
+	146: 242,   // Leftovers
+	150: 10061, // Mystery Berry
+	151: 108,   // Dragon Scale
+	152: 388,   // Berserk Gene
+	156: 10018, // Sacred Ash
+	157: 194,   // Heavy Ball
+	159: 246,   // Level Ball
+	160: 264,   // Lure Ball
+	161: 137,   // Fast Ball
+	163: 251,   // Light Ball
+	164: 153,   // Friend Ball
+	165: 294,   // Moon Ball
+	166: 258,   // Love Ball
+	169: 480,   // Sun Stone
+	170: 10062, // Polkadot Bow
+	172: 523,   // Up-Grade
+	173: 10063, // Berry
+	174: 10064, // Gold Berry
+	177: 325,   // Park Ball
+}
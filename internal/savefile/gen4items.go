@@ -0,0 +1,309 @@
+package savefile
+
+// gen4ItemToShowdown maps the item IDs of Diamond, Pearl, Platinum, HeartGold and SoulSilver
+// to Showdown item numbers
+// Key items, TMs, HMs and mail have no Showdown equivalent and are left out
+var gen4ItemToShowdown = map[int]int{
+	1:   276,   // Master Ball
+	2:   521,   // Ultra Ball
+	3:   174,   // Great Ball
+	4:   345,   // Poke Ball
+	5:   425,   // Safari Ball
+	6:   304,   // Net Ball
+	7:   101,   // Dive Ball
+	8:   303,   // Nest Ball
+	9:   401,   // Repeat Ball
+	10:  494,   // Timer Ball
+	11:  266,   // Luxury Ball
+	12:  363,   // Premier Ball
+	13:  115,   // Dusk Ball
+	14:  188,   // Heal Ball
+	15:  372,   // Quick Ball
+	16:  64,    // Cherish Ball
+	17:  10003, // Potion
+	18:  18,    // Antidote
+	19:  19,    // Burn Heal
+	20:  20,    // Ice Heal
+	21:  10004, // Awakening
+	22:  10005, // Paralyze Heal
+	23:  23,    // Full Restore
+	24:  24,    // Max Potion
+	25:  25,    // Hyper Potion
+	26:  26,    // Super Potion
+	27:  10006, // Full Heal
+	28:  28,    // Revive
+	29:  10007, // Max Revive
+	30:  30,    // Fresh Water
+	31:  10008, // Soda Pop
+	32:  10009, // Lemonade
+	33:  33,    // Moomoo Milk
+	34:  10010, // Energy Powder
+	35:  10011, // Energy Root
+	36:  36,    // Heal Powder
+	37:  37,    // Revival Herb
+	38:  38,    // Ether
+	39:  39,    // Max Ether
+	40:  40,    // Elixir
+	41:  10012, // Max Elixir
+	42:  42,    // Lava Cookie
+	43:  22,    // Berry Juice
+	44:  10018, // Sacred Ash
+	45:  45,    // HP Up
+	46:  46,    // Protein
+	47:  47,    // Iron
+	48:  48,    // Carbos
+	49:  49,    // Calcium
+	50:  50,    // Rare Candy
+	51:  10022, // PP Up
+	52:  52,    // Zinc
+	53:  10023, // PP Max
+	54:  10041, // Old Gateau
+	55:  55,    // Guard Spec.
+	56:  56,    // Dire Hit
+	57:  57,    // X Attack
+	58:  58,    // X Defense
+	59:  59,    // X Speed
+	60:  10024, // X Accuracy
+	61:  10025, // X Sp. Atk
+	62:  10042, // X Sp. Def
+	63:  10026, // Poké Doll
+	64:  10027, // Fluffy Tail
+	65:  10013, // Blue Flute
+	66:  10014, // Yellow Flute
+	67:  10015, // Red Flute
+	68:  10016, // Black Flute
+	69:  10017, // White Flute
+	70:  10019, // Shoal Salt
+	71:  10020, // Shoal Shell
+	72:  10021, // Red Shard
+	73:  73,    // Blue Shard
+	74:  74,    // Yellow Shard
+	75:  75,    // Green Shard
+	76:  10028, // Super Repel
+	77:  77,    // Max Repel
+	78:  10029, // Escape Rope
+	79:  79,    // Repel
+	80:  480,   // Sun Stone
+	81:  295,   // Moon Stone
+	82:  142,   // Fire Stone
+	83:  492,   // Thunder Stone
+	84:  529,   // Water Stone
+	85:  241,   // Leaf Stone
+	86:  10030, // Tiny Mushroom
+	87:  87,    // Big Mushroom
+	88:  10031, // Pearl
+	89:  10032, // Big Pearl
+	90:  90,    // Stardust
+	91:  91,    // Star Piece
+	92:  10033, // Nugget
+	93:  10034, // Heart Scale
+	94:  10043, // Honey
+	95:  10044, // Growth Mulch
+	96:  96,    // Damp Mulch
+	97:  97,    // Stable Mulch
+	98:  98,    // Gooey Mulch
+	99:  418,   // Root Fossil
+	100: 72,    // Claw Fossil
+	101: 195,   // Helix Fossil
+	102: 102,   // Dome Fossil
+	103: 314,   // Old Amber
+	104: 12,    // Armor Fossil
+	105: 449,   // Skull Fossil
+	106: 379,   // Rare Bone
+	107: 439,   // Shiny Stone
+	108: 116,   // Dusk Stone
+	109: 92,    // Dawn Stone
+	110: 321,   // Oval Stone
+	111: 10045, // Odd Keystone
+	112: 180,   // Griseous Orb
+	135: 4,     // Adamant Orb
+	136: 265,   // Lustrous Orb
+	149: 63,    // Cheri Berry
+	150: 65,    // Chesto Berry
+	151: 333,   // Pecha Berry
+	152: 381,   // Rawst Berry
+	153: 13,    // Aspear Berry
+	154: 244,   // Leppa Berry
+	155: 319,   // Oran Berry
+	156: 334,   // Persim Berry
+	157: 262,   // Lum Berry
+	158: 448,   // Sitrus Berry
+	159: 140,   // Figy Berry
+	160: 538,   // Wiki Berry
+	161: 274,   // Mago Berry
+	162: 5,     // Aguav Berry
+	163: 217,   // Iapapa Berry
+	164: 384,   // Razz Berry
+	165: 44,    // Bluk Berry
+	166: 302,   // Nanab Berry
+	167: 533,   // Wepear Berry
+	168: 337,   // Pinap Berry
+	169: 351,   // Pomeg Berry
+	170: 235,   // Kelpsy Berry
+	171: 371,   // Qualot Berry
+	172: 213,   // Hondew Berry
+	173: 178,   // Grepa Berry
+	174: 486,   // Tamato Berry
+	175: 81,    // Cornn Berry
+	176: 275,   // Magost Berry
+	177: 375,   // Rabuta Berry
+	178: 306,   // Nomel Berry
+	179: 462,   // Spelon Berry
+	180: 323,   // Pamtre Berry
+	181: 530,   // Watmel Berry
+	182: 114,   // Durin Berry
+	183: 21,    // Belue Berry
+	184: 311,   // Occa Berry
+	185: 329,   // Passho Berry
+	186: 526,   // Wacan Berry
+	187: 409,   // Rindo Berry
+	188: 567,   // Yache Berry
+	189: 71,    // Chople Berry
+	190: 234,   // Kebia Berry
+	191: 443,   // Shuca Berry
+	192: 76,    // Coba Berry
+	193: 330,   // Payapa Berry
+	194: 487,   // Tanga Berry
+	195: 62,    // Charti Berry
+	196: 233,   // Kasib Berry
+	197: 185,   // Haban Berry
+	198: 78,    // Colbur Berry
+	199: 17,    // Babiri Berry
+	200: 66,    // Chilan Berry
+	201: 248,   // Liechi Berry
+	202: 158,   // Ganlon Berry
+	203: 426,   // Salac Berry
+	204: 335,   // Petaya Berry
+	205: 10,    // Apicot Berry
+	206: 238,   // Lansat Berry
+	207: 472,   // Starf Berry
+	208: 124,   // Enigma Berry
+	209: 290,   // Micle Berry
+	210: 86,    // Custap Berry
+	211: 230,   // Jaboca Berry
+	212: 420,   // Rowap Berry
+	213: 51,    // Bright Powder
+	214: 535,   // White Herb
+	215: 269,   // Macho Brace
+	216: 10035, // Exp. Share
+	217: 10036, // Quick Claw
+	218: 10037, // Soothe Bell
+	219: 285,   // Mental Herb
+	220: 68,    // Choice Band
+	221: 236,   // King's Rock
+	222: 447,   // Silver Powder
+	223: 200,   // Amulet Coin
+	224: 201,   // Cleanse Tag
+	225: 459,   // Soul Dew
+	226: 94,    // Deep Sea Tooth
+	227: 93,    // Deep Sea Scale
+	228: 205,   // Smoke Ball
+	229: 206,   // Everstone
+	230: 150,   // Focus Band
+	231: 208,   // Lucky Egg
+	232: 429,   // Scope Lens
+	233: 286,   // Metal Coat
+	234: 242,   // Leftovers
+	235: 108,   // Dragon Scale
+	236: 251,   // Light Ball
+	237: 456,   // Soft Sand
+	238: 187,   // Hard Stone
+	239: 10038, // Miracle Seed
+	240: 35,    // Black Glasses
+	241: 32,    // Black Belt
+	242: 273,   // Magnet
+	243: 300,   // Mystic Water
+	244: 436,   // Sharp Beak
+	245: 343,   // Poison Barb
+	246: 305,   // Never-Melt Ice
+	247: 461,   // Spell Tag
+	248: 520,   // Twisted Spoon
+	249: 61,    // Charcoal
+	250: 106,   // Dragon Fang
+	251: 444,   // Silk Scarf
+	252: 523,   // Up-Grade
+	253: 438,   // Shell Bell
+	254: 430,   // Sea Incense
+	255: 240,   // Lax Incense
+	256: 261,   // Lucky Punch
+	257: 10039, // Metal Powder
+	258: 491,   // Thick Club
+	259: 10040, // Stick
+	260: 10046, // Red Scarf
+	261: 10047, // Blue Scarf
+	262: 239,   // Pink Scarf
+	263: 10048, // Green Scarf
+	264: 10049, // Yellow Scarf
+	265: 537,   // Wide Lens
+	266: 297,   // Muscle Band
+	267: 539,   // Wise Glasses
+	268: 132,   // Expert Belt
+	269: 252,   // Light Clay
+	270: 249,   // Life Orb
+	271: 10050, // Power Herb
+	272: 515,   // Toxic Orb
+	273: 145,   // Flame Orb
+	274: 374,   // Quick Powder
+	275: 151,   // Focus Sash
+	276: 574,   // Zoom Lens
+	277: 289,   // Metronome
+	278: 224,   // Iron Ball
+	279: 237,   // Lagging Tail
+	280: 95,    // Destiny Knot
+	281: 34,    // Black Sludge
+	282: 221,   // Icy Rock
+	283: 453,   // Smooth Rock
+	284: 193,   // Heat Rock
+	285: 88,    // Damp Rock
+	286: 179,   // Grip Claw
+	287: 69,    // Choice Scarf
+	288: 476,   // Sticky Barb
+	289: 357,   // Power Bracer
+	290: 356,   // Power Belt
+	291: 359,   // Power Lens
+	292: 355,   // Power Band
+	293: 354,   // Power Anklet
+	294: 360,   // Power Weight
+	295: 437,   // Shed Shell
+	296: 29,    // Big Root
+	297: 70,    // Choice Specs
+	298: 146,   // Flame Plate
+	299: 463,   // Splash Plate
+	300: 572,   // Zap Plate
+	301: 282,   // Meadow Plate
+	302: 220,   // Icicle Plate
+	303: 143,   // Fist Plate
+	304: 516,   // Toxic Plate
+	305: 117,   // Earth Plate
+	306: 450,   // Sky Plate
+	307: 291,   // Mind Plate
+	308: 223,   // Insect Plate
+	309: 477,   // Stone Plate
+	310: 464,   // Spooky Plate
+	311: 105,   // Draco Plate
+	312: 110,   // Dread Plate
+	313: 225,   // Iron Plate
+	314: 312,   // Odd Incense
+	315: 416,   // Rock Incense
+	316: 155,   // Full Incense
+	317: 531,   // Wave Incense
+	318: 419,   // Rose Incense
+	319: 296,   // Luck Incense
+	320: 10051, // Pure Incense
+	321: 367,   // Protector
+	322: 119,   // Electirizer
+	323: 272,   // Magmarizer
+	324: 113,   // Dubious Disc
+	325: 385,   // Reaper Cloth
+	326: 382,   // Razor Claw
+	327: 383,   // Razor Fang
+	492: 137,   // Fast Ball
+	493: 246,   // Level Ball
+	494: 264,   // Lure Ball
+	495: 194,   // Heavy Ball
+	496: 258,   // Love Ball
+	497: 153,   // Friend Ball
+	498: 294,   // Moon Ball
+	499: 465,   // Sport Ball
+	500: 325,   // Park Ball
+}
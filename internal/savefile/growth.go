@@ -0,0 +1,88 @@
+package savefile
+
+// GrowthRate is one of the six experience curves
+type GrowthRate string
+
+const (
+	GrowthErratic     GrowthRate = "Erratic"
+	GrowthFast        GrowthRate = "Fast"
+	GrowthMediumFast  GrowthRate = "Medium Fast"
+	GrowthMediumSlow  GrowthRate = "Medium Slow"
+	GrowthSlow        GrowthRate = "Slow"
+	GrowthFluctuating GrowthRate = "Fluctuating"
+)
+
+// ExpForLevel returns the experience needed to reach a level
+// Unknown growth rates use the Medium Fast curve
+func (g GrowthRate) ExpForLevel(level int) uint32 {
+	if level <= 1 {
+		return 0
+	}
+	if level > 100 {
+		level = 100
+	}
+	n := int64(level)
+	cube := n * n * n
+
+	var exp int64
+	switch g {
+	case GrowthErratic:
+		switch {
+		case n < 50:
+			exp = cube * (100 - n) / 50
+		case n < 68:
+			exp = cube * (150 - n) / 100
+		case n < 98:
+			exp = cube * ((1911 - 10*n) / 3) / 500
+		default:
+			exp = cube * (160 - n) / 100
+		}
+	case GrowthFast:
+		exp = 4 * cube / 5
+	case GrowthMediumSlow:
+		exp = 6*cube/5 - 15*n*n + 100*n - 140
+	case GrowthSlow:
+		exp = 5 * cube / 4
+	case GrowthFluctuating:
+		switch {
+		case n < 15:
+			exp = cube * ((n+1)/3 + 24) / 50
+		case n < 36:
+			exp = cube * (n + 14) / 50
+		default:
+			exp = cube * (n/2 + 32) / 50
+		}
+	default:
+		exp = cube
+	}
+	return uint32(exp)
+}
+
+// LevelForExp returns the level reached with an amount of experience
+func (g GrowthRate) LevelForExp(exp uint32) int {
+	for level := 100; level > 1; level-- {
+		if exp >= g.ExpForLevel(level) {
+			return level
+		}
+	}
+	return 1
+}
+
+// ExpToNextLevel returns the experience still needed to reach the next level, or 0 at level 100
+func (g GrowthRate) ExpToNextLevel(exp uint32) uint32 {
+	level := g.LevelForExp(exp)
+	if level >= 100 {
+		return 0
+	}
+	return g.ExpForLevel(level+1) - exp
+}
+
+// ApplyGrowthRates recomputes box Pokemon levels from their experience with each species'
+// growth rate, since the box structure doesn't store the level
+func (r *ParseResult) ApplyGrowthRates(rate func(speciesNum int) GrowthRate) {
+	for _, box := range r.Boxes {
+		for i := range box {
+			box[i].Level = rate(box[i].SpeciesNum).LevelForExp(box[i].Experience)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package savefile
+
+import "encoding/binary"
+
+// Game is a game family; games in a family share a save layout
+type Game string
+
+const (
+	GameRubySapphire     Game = "rubysapphire"
+	GameEmerald          Game = "emerald"
+	GameFireRedLeafGreen Game = "fireredleafgreen"
+)
+
+// Generation returns the generation of a game family: 1-4
+func (g Game) Generation() int {
+	switch g {
+	case GameRedBlueYellow:
+		return 1
+	case GameGoldSilver, GameCrystal:
+		return 2
+	case GameDiamondPearl, GamePlatinum, GameHeartGoldSoulSilver:
+		return 4
+	}
+	return 3
+}
+
+// Section 0 (SaveBlock2) offsets used to detect the game
+const (
+	gameCodeOffset   = 0xAC // 0 in Ruby/Sapphire, 1 in FireRed/LeafGreen, the security key in Emerald
+	gameCodeRS       = 0
+	gameCodeFRLG     = 1
+	saveBlock1Blocks = 4 // SaveBlock1 is split across sections 1-4
+	sectionDataSize  = 0xF80
+)
+
+// PC storage layout, the same in every Gen 3 game
+const (
+	pcHeaderSize  = 4 // Current box index, before the box Pokemon
+	pcBoxes       = 14
+	pokemonPerBox = 30
+)
+
+// pocket is the offset in SaveBlock1 and the capacity of a bag pocket
+type pocket struct {
+	offset int
+	slots  int
+}
+
+// storyFlag is a system flag reported in Progress.StoryFlags
+type storyFlag struct {
+	name string
+	flag int
+}
+
+// layout holds the offsets that differ between the Gen 3 games
+// SaveBlock1 offsets are from the start of section 1, with sections 1-4 joined end to end
+type layout struct {
+	game           Game
+	saveBlock2Size int // Checksummed size of section 0, unique to each game
+	keyOffset      int // Security key in section 0 that bag quantities and money are XORed with; -1 if unencrypted
+
+	partyOffset int // Party count, followed by the party
+	moneyOffset int
+	coinsOffset int
+
+	pcItems   pocket
+	items     pocket
+	keyItems  pocket
+	pokeBalls pocket
+	tmsHMs    pocket
+	berries   pocket
+
+	flagsOffset     int
+	flagsSize       int
+	trainerFlagBase int // Flag of trainer 0; trainer N's flag is trainerFlagBase+N
+	maxTrainerID    int // Trainer IDs that have a flag
+	badgeFlagBase   int // FLAG_BADGE01_GET; the other badges follow in gym order
+	badges          []string
+	storyFlags      []storyFlag
+}
+
+// hoennBadges and kantoBadges are the badges in gym order
+var (
+	hoennBadges = []string{"Stone", "Knuckle", "Dynamo", "Heat", "Balance", "Feather", "Mind", "Rain"}
+	kantoBadges = []string{"Boulder", "Cascade", "Thunder", "Rainbow", "Soul", "Marsh", "Volcano", "Earth"}
+)
+
+// rubySapphireLayout is the save layout of Ruby and Sapphire, which don't encrypt bag quantities
+var rubySapphireLayout = &layout{
+	game:           GameRubySapphire,
+	saveBlock2Size: 2192,
+	keyOffset:      -1,
+
+	partyOffset: 0x0234,
+	moneyOffset: 0x0490,
+	coinsOffset: 0x0494,
+
+	pcItems:   pocket{0x0498, 50},
+	items:     pocket{0x0560, 20},
+	keyItems:  pocket{0x05B0, 20},
+	pokeBalls: pocket{0x0600, 16},
+	tmsHMs:    pocket{0x0640, 64},
+	berries:   pocket{0x0740, 46},
+
+	flagsOffset:     0x1220,
+	flagsSize:       288,
+	trainerFlagBase: 0x500,
+	maxTrainerID:    693,
+	badgeFlagBase:   0x807,
+	badges:          hoennBadges,
+	storyFlags: []storyFlag{
+		{"starterReceived", 0x800},
+		{"pokedexReceived", 0x801},
+		{"pokenavReceived", 0x802},
+		{"hallOfFame", 0x804},
+		{"nationalDex", 0x836},
+	},
+}
+
+// emeraldLayout is the save layout of Emerald
+var emeraldLayout = &layout{
+	game:           GameEmerald,
+	saveBlock2Size: 3884,
+	keyOffset:      0xAC,
+
+	partyOffset: 0x0234,
+	moneyOffset: 0x0490,
+	coinsOffset: 0x0494,
+
+	pcItems:   pocket{0x0498, 50},
+	items:     pocket{0x0560, 30},
+	keyItems:  pocket{0x05D8, 30},
+	pokeBalls: pocket{0x0650, 16},
+	tmsHMs:    pocket{0x0690, 64},
+	berries:   pocket{0x0790, 46},
+
+	flagsOffset:     0x1270,
+	flagsSize:       300,
+	trainerFlagBase: 0x500,
+	maxTrainerID:    864,
+	badgeFlagBase:   0x867,
+	badges:          hoennBadges,
+	storyFlags: []storyFlag{
+		{"starterReceived", 0x860},
+		{"pokedexReceived", 0x861},
+		{"pokenavReceived", 0x862},
+		{"hallOfFame", 0x864},
+		{"nationalDex", 0x896},
+	},
+}
+
+// fireRedLeafGreenLayout is the save layout of FireRed and LeafGreen
+var fireRedLeafGreenLayout = &layout{
+	game:           GameFireRedLeafGreen,
+	saveBlock2Size: 3876,
+	keyOffset:      0x0F20,
+
+	partyOffset: 0x0034,
+	moneyOffset: 0x0290,
+	coinsOffset: 0x0294,
+
+	pcItems:   pocket{0x0298, 30},
+	items:     pocket{0x0310, 42},
+	keyItems:  pocket{0x03B8, 30},
+	pokeBalls: pocket{0x0430, 13},
+	tmsHMs:    pocket{0x0464, 58},
+	berries:   pocket{0x054C, 43},
+
+	flagsOffset:     0x0EE0,
+	flagsSize:       288,
+	trainerFlagBase: 0x500,
+	maxTrainerID:    743,
+	badgeFlagBase:   0x820,
+	badges:          kantoBadges,
+	storyFlags: []storyFlag{
+		{"starterReceived", 0x828},
+		{"pokedexReceived", 0x829},
+		{"hallOfFame", 0x82C},
+	},
+}
+
+// layouts lists every known layout, smallest section 0 first
+var layouts = []*layout{rubySapphireLayout, fireRedLeafGreenLayout, emeraldLayout}
+
+// detectLayout works out which game a save slot is from
+// The game code at 0xAC picks the game, checked against section 0's checksum: sectors are
+// zero-padded, so a section 0 checksum matches its own game's size and any larger size.
+// If the game code's game doesn't match, the largest matching size wins. A section 0 matching
+// none is a ROM hack that resized it; those are almost all built on Emerald, so only a
+// FireRed/LeafGreen game code overrides the Emerald default.
+func detectLayout(data []byte, slotBase int) *layout {
+	section0 := sectionOffset(data, slotBase, 0)
+	if section0 == -1 {
+		return emeraldLayout
+	}
+
+	byCode := emeraldLayout
+	switch binary.LittleEndian.Uint32(data[section0+gameCodeOffset : section0+gameCodeOffset+4]) {
+	case gameCodeRS:
+		byCode = rubySapphireLayout
+	case gameCodeFRLG:
+		byCode = fireRedLeafGreenLayout
+	}
+
+	checksum := binary.LittleEndian.Uint16(data[section0+0xFF6 : section0+0xFF8])
+	var matched *layout
+	for _, l := range layouts {
+		if sectionChecksum(data[section0:section0+l.saveBlock2Size]) != checksum {
+			continue
+		}
+		if l == byCode {
+			return l
+		}
+		matched = l
+	}
+
+	switch {
+	case matched != nil:
+		return matched
+	case byCode == fireRedLeafGreenLayout:
+		return fireRedLeafGreenLayout
+	default:
+		return emeraldLayout
+	}
+}
+
+// saveBlock1 joins sections 1-4 of a save slot, or returns nil if any of them is missing
+func saveBlock1(data []byte, slotBase int) []byte {
+	return joinSections(data, slotBase, 1, saveBlock1Blocks)
+}
+
+// pcStorage joins sections 5-13 of a save slot, or returns nil if any of them is missing
+func pcStorage(data []byte, slotBase int) []byte {
+	return joinSections(data, slotBase, 5, sectionsPerSlot-1)
+}
+
+// joinSections joins the data of sections first-last in order, or returns nil if any is missing
+func joinSections(data []byte, slotBase, first, last int) []byte {
+	block := make([]byte, 0, (last-first+1)*sectionDataSize)
+	for sectionID := first; sectionID <= last; sectionID++ {
+		section := sectionOffset(data, slotBase, sectionID)
+		if section == -1 {
+			return nil
+		}
+		block = append(block, data[section:section+sectionDataSize]...)
+	}
+	return block
+}
@@ -0,0 +1,195 @@
+package savefile
+
+import "strconv"
+
+// Special met locations
+const (
+	LocationInGameTrade      = 0xFE
+	LocationFatefulEncounter = 0xFF
+)
+
+// hoennLocations are the Ruby/Sapphire/Emerald map section names, by map section ID
+var hoennLocations = map[int]string{
+	0x00: "Littleroot Town",
+	0x01: "Oldale Town",
+	0x02: "Dewford Town",
+	0x03: "Lavaridge Town",
+	0x04: "Fallarbor Town",
+	0x05: "Verdanturf Town",
+	0x06: "Pacifidlog Town",
+	0x07: "Petalburg City",
+	0x08: "Slateport City",
+	0x09: "Mauville City",
+	0x0A: "Rustboro City",
+	0x0B: "Fortree City",
+	0x0C: "Lilycove City",
+	0x0D: "Mossdeep City",
+	0x0E: "Sootopolis City",
+	0x0F: "Ever Grande City",
+	// 0x10-0x31 are Route 101-134, see LocationName
+	0x32: "Underwater (Route 124)",
+	0x33: "Underwater (Route 126)",
+	0x34: "Underwater (Route 127)",
+	0x35: "Underwater (Route 128)",
+	0x36: "Underwater (Sootopolis City)",
+	0x37: "Granite Cave",
+	0x38: "Mt. Chimney",
+	0x39: "Safari Zone",
+	0x3A: "Battle Frontier",
+	0x3B: "Petalburg Woods",
+	0x3C: "Rusturf Tunnel",
+	0x3D: "Abandoned Ship",
+	0x3E: "New Mauville",
+	0x3F: "Meteor Falls",
+	0x40: "Meteor Falls",
+	0x41: "Mt. Pyre",
+	0x42: "Hideout",
+	0x43: "Shoal Cave",
+	0x44: "Seafloor Cavern",
+	0x45: "Underwater (Seafloor Cavern)",
+	0x46: "Victory Road",
+	0x47: "Mirage Island",
+	0x48: "Cave of Origin",
+	0x49: "Southern Island",
+	0x4A: "Fiery Path",
+	0x4B: "Fiery Path",
+	0x4C: "Jagged Pass",
+	0x4D: "Jagged Pass",
+	0x4E: "Sealed Chamber",
+	0x4F: "Underwater (Sealed Chamber)",
+	0x50: "Scorched Slab",
+	0x51: "Island Cave",
+	0x52: "Desert Ruins",
+	0x53: "Ancient Tomb",
+	0x54: "Inside of Truck",
+	0x55: "Sky Pillar",
+	0x56: "Secret Base",
+	0x57: "Ferry",
+	// Emerald only, after the Kanto sections
+	0xC5: "Aqua Hideout",
+	0xC6: "Magma Hideout",
+	0xC7: "Mirage Tower",
+	0xC8: "Birth Island",
+	0xC9: "Faraway Island",
+	0xCA: "Artisan Cave",
+	0xCB: "Marine Cave",
+	0xCC: "Underwater (Marine Cave)",
+	0xCD: "Terra Cave",
+	0xCE: "Underwater (Route 105)",
+	0xCF: "Underwater (Route 125)",
+	0xD0: "Underwater (Route 129)",
+	0xD1: "Desert Underpass",
+	0xD2: "Altering Cave",
+	0xD3: "Navel Rock",
+	0xD4: "Trainer Hill",
+
+	LocationInGameTrade:      "In-game Trade",
+	LocationFatefulEncounter: "Fateful Encounter",
+}
+
+// kantoLocations are the FireRed/LeafGreen map section names, by map section ID
+// Emerald shares the IDs, so Pokemon traded from FireRed/LeafGreen keep their met location
+var kantoLocations = map[int]string{
+	0x58: "Pallet Town",
+	0x59: "Viridian City",
+	0x5A: "Pewter City",
+	0x5B: "Cerulean City",
+	0x5C: "Lavender Town",
+	0x5D: "Vermilion City",
+	0x5E: "Celadon City",
+	0x5F: "Fuchsia City",
+	0x60: "Cinnabar Island",
+	0x61: "Indigo Plateau",
+	0x62: "Saffron City",
+	0x63: "Route 4",
+	0x64: "Route 10",
+	// 0x65-0x7D are Route 1-25, see LocationName
+	0x7E: "Viridian Forest",
+	0x7F: "Mt. Moon",
+	0x80: "S.S. Anne",
+	0x81: "Underground Path",
+	0x82: "Underground Path",
+	0x83: "Diglett's Cave",
+	0x84: "Victory Road",
+	0x85: "Rocket Hideout",
+	0x86: "Silph Co.",
+	0x87: "Pokemon Mansion",
+	0x88: "Safari Zone",
+	0x89: "Pokemon League",
+	0x8A: "Rock Tunnel",
+	0x8B: "Seafoam Islands",
+	0x8C: "Pokemon Tower",
+	0x8D: "Cerulean Cave",
+	0x8E: "Power Plant",
+	0x8F: "One Island",
+	0x90: "Two Island",
+	0x91: "Three Island",
+	0x92: "Four Island",
+	0x93: "Five Island",
+	0x94: "Seven Island",
+	0x95: "Six Island",
+	0x96: "Kindle Road",
+	0x97: "Treasure Beach",
+	0x98: "Cape Brink",
+	0x99: "Bond Bridge",
+	0x9A: "Three Isle Port",
+	0x9B: "Sevii Isle 6",
+	0x9C: "Sevii Isle 7",
+	0x9D: "Sevii Isle 8",
+	0x9E: "Sevii Isle 9",
+	0x9F: "Resort Gorgeous",
+	0xA0: "Water Labyrinth",
+	0xA1: "Five Isle Meadow",
+	0xA2: "Memorial Pillar",
+	0xA3: "Outcast Island",
+	0xA4: "Green Path",
+	0xA5: "Water Path",
+	0xA6: "Ruin Valley",
+	0xA7: "Trainer Tower",
+	0xA8: "Canyon Entrance",
+	0xA9: "Sevault Canyon",
+	0xAA: "Tanoby Ruins",
+	0xAB: "Sevii Isle 22",
+	0xAC: "Sevii Isle 23",
+	0xAD: "Sevii Isle 24",
+	0xAE: "Navel Rock",
+	0xAF: "Mt. Ember",
+	0xB0: "Berry Forest",
+	0xB1: "Icefall Cave",
+	0xB2: "Rocket Warehouse",
+	0xB3: "Trainer Tower",
+	0xB4: "Dotted Hole",
+	0xB5: "Lost Cave",
+	0xB6: "Pattern Bush",
+	0xB7: "Altering Cave",
+	0xB8: "Tanoby Chambers",
+	0xB9: "Three Isle Path",
+	0xBA: "Tanoby Key",
+	0xBB: "Birth Island",
+	0xBC: "Monean Chamber",
+	0xBD: "Liptoo Chamber",
+	0xBE: "Weepth Chamber",
+	0xBF: "Dilford Chamber",
+	0xC0: "Scufib Chamber",
+	0xC1: "Rixy Chamber",
+	0xC2: "Viapois Chamber",
+	0xC3: "Ember Spa",
+	0xC4: "Special Area",
+}
+
+// LocationName returns the name of a met location (map section ID)
+func LocationName(id int) string {
+	if id >= 0x10 && id <= 0x31 {
+		return "Route " + strconv.Itoa(101+id-0x10)
+	}
+	if id >= 0x65 && id <= 0x7D {
+		return "Route " + strconv.Itoa(1+id-0x65)
+	}
+	if name, ok := hoennLocations[id]; ok {
+		return name
+	}
+	if name, ok := kantoLocations[id]; ok {
+		return name
+	}
+	return "Unknown"
+}
@@ -0,0 +1,88 @@
+package savefile
+
+import "encoding/binary"
+
+// Origin is where and how a Pokemon was obtained, from the Misc substructure and the OT name
+type Origin struct {
+	MetLocation int    `json:"metLocation"` // Map section ID in Gen 3, see LocationName; location ID in Crystal and Gen 4
+	MetLevel    int    `json:"metLevel"`    // 0 if hatched from an egg
+	BallItemNum int    `json:"ballItemNum"` // Showdown item number of the ball it was caught in
+	Game        int    `json:"game"`        // Origin game ID, see GameName
+	OTName      string `json:"otName"`
+	OTGender    string `json:"otGender"` // "male" or "female"
+}
+
+// Pokerus is a Pokemon's Pokerus strain and the days left until it's cured
+type Pokerus struct {
+	Strain int `json:"strain"`
+	Days   int `json:"days"`
+}
+
+// Status returns "infected", "cured" or "none"
+func (p Pokerus) Status() string {
+	switch {
+	case p.Days > 0:
+		return "infected"
+	case p.Strain > 0:
+		return "cured"
+	default:
+		return "none"
+	}
+}
+
+// originGames are the games a Gen 3 or Gen 4 Pokemon can come from, by origin game ID
+var originGames = map[int]string{
+	0:  "Colosseum Bonus Disc",
+	1:  "Sapphire",
+	2:  "Ruby",
+	3:  "Emerald",
+	4:  "FireRed",
+	5:  "LeafGreen",
+	7:  "HeartGold",
+	8:  "SoulSilver",
+	10: "Diamond",
+	11: "Pearl",
+	12: "Platinum",
+	15: "Colosseum/XD",
+}
+
+// GameName returns the name of an origin game ID
+func GameName(id int) string {
+	if name, ok := originGames[id]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// parseOrigin reads the origin data of a decrypted 100- or 80-byte Pokemon record
+// The OT name is at bytes 20-26 of the unencrypted header
+func parseOrigin(data, decryptedData []byte, growthPos, miscPos int, p *profile) Origin {
+	origins := binary.LittleEndian.Uint16(decryptedData[miscPos+2 : miscPos+4])
+	origin := Origin{
+		MetLocation: int(decryptedData[miscPos+1]),
+		MetLevel:    int(origins & 0x7F),
+		Game:        int((origins >> 7) & 0xF),
+		OTName:      decodeGen3String(data[20:27]),
+		OTGender:    "male",
+	}
+	if origins&0x8000 != 0 {
+		origin.OTGender = "female"
+	}
+
+	// pokeemerald-expansion moved the ball to the low 6 bits of Growth bytes 10-11,
+	// vanilla keeps it in bits 11-14 of the origins word
+	// Ball IDs match the item IDs of the balls
+	ball := int((origins >> 11) & 0xF)
+	if p.packedGrowth {
+		ball = int(binary.LittleEndian.Uint16(decryptedData[growthPos+10:growthPos+12]) & 0x3F)
+	}
+	origin.BallItemNum = p.itemNum(ball)
+
+	return origin
+}
+
+// parsePokerus reads the Pokerus byte of the Misc substructure
+// The high nibble is the strain and the low nibble the days remaining
+func parsePokerus(b byte) Pokerus {
+	return Pokerus{Strain: int(b >> 4), Days: int(b & 0xF)}
+}
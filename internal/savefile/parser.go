@@ -17,6 +17,8 @@ type PokemonStats struct {
 
 // PartyPokemon represents a Pokemon in the party
 type PartyPokemon struct {
+	Personality uint32       `json:"personality"`
+	OTID        uint32       `json:"otId"`
 	Species     string       `json:"species"`
 	Nickname    string       `json:"nickname"`
 	Level       int          `json:"level"`
@@ -30,14 +32,25 @@ type PartyPokemon struct {
 	IVs         PokemonStats `json:"ivs"`
 	EVs         PokemonStats `json:"evs"`
 	CurrentHP   int          `json:"currentHp"`
+	Status      Status       `json:"status"`
+	PP          []int        `json:"pp"`          // Current PP of each move in MoveNums
+	PPUps       []int        `json:"ppUps"`       // PP Ups applied to each move in MoveNums (0-3)
 	AbilitySlot int          `json:"abilitySlot"` // 0 = first ability, 1 = second ability
+	Experience  uint32       `json:"experience"`
 	Friendship  int          `json:"friendship"`
+	Origin      Origin       `json:"origin"`
+	Pokerus     Pokerus      `json:"pokerus"`
+	Traits      Traits       `json:"traits"`
+
+	raw []byte // Header, decrypted substructures and party data of a Gen 3 record, see PK3
 }
 
 // BoxPokemon represents a Pokemon in PC storage (80 bytes, no calculated stats)
 type BoxPokemon struct {
+	Personality uint32       `json:"personality"`
+	OTID        uint32       `json:"otId"`
 	Nickname    string       `json:"nickname"`
-	Level       int          `json:"level"` // Calculated from experience
+	Level       int          `json:"level"` // Calculated from experience, see ApplyGrowthRates
 	SpeciesNum  int          `json:"speciesNum"`
 	Nature      string       `json:"nature"`
 	ItemNum     int          `json:"itemNum"`
@@ -47,6 +60,11 @@ type BoxPokemon struct {
 	AbilitySlot int          `json:"abilitySlot"`
 	Experience  uint32       `json:"experience"`
 	Friendship  int          `json:"friendship"`
+	Origin      Origin       `json:"origin"`
+	Pokerus     Pokerus      `json:"pokerus"`
+	Traits      Traits       `json:"traits"`
+
+	raw []byte // Header and decrypted substructures of a Gen 3 record, see PK3
 }
 
 // BagItem represents an item in the player's bag or PC storage
@@ -67,9 +85,15 @@ type BagPockets struct {
 
 // ParseResult contains the parsed save data
 type ParseResult struct {
-	Party []PartyPokemon `json:"party"`
-	Boxes [][]BoxPokemon `json:"boxes"` // 14 boxes, each up to 30 Pokemon
-	Bag   *BagPockets    `json:"bag"`   // Bag and PC item storage
+	Game      Game           `json:"game"`   // Game family detected from the save layout
+	Format    Format         `json:"format"` // Vanilla or the ROM hack engine the save was decoded as
+	Party     []PartyPokemon `json:"party"`
+	Boxes     [][]BoxPokemon `json:"boxes"`              // 14 boxes in Gen 3 or 18 in Gen 4, each up to 30 Pokemon
+	Bag       *BagPockets    `json:"bag"`                // Bag and PC item storage
+	Progress  *Progress      `json:"progress,omitempty"` // Badges and event flags; nil if unreadable
+	Trainer   *Trainer       `json:"trainer,omitempty"`  // Trainer card, money and coins
+	Pokedex   *Pokedex       `json:"pokedex,omitempty"`  // Owned and seen National Dex numbers
+	Integrity *Integrity     `json:"integrity"`          // Checksums of both save slots and which was parsed
 }
 
 // Substructure order lookup table (personality % 24)
@@ -651,8 +675,26 @@ var gen3CharTable = map[byte]rune{
 	0xFF: 0, // Terminator
 }
 
+// ParseSave parses a Gen 1-4 save, detecting the generation from its size and layout
+// The format only applies to Gen 3 saves; the other generations have a single encoding
+func ParseSave(data []byte, format Format) (*ParseResult, error) {
+	switch {
+	case IsGen4Save(data):
+		return ParseGen4Save(data)
+	case IsRetroSave(data):
+		return ParseRetroSave(data)
+	}
+	return ParseGen3SaveFormat(data, format)
+}
+
 // ParseGen3Save parses a Gen 3 Pokemon save file and extracts party and box Pokemon
+// The format is detected from the save's Pokemon
 func ParseGen3Save(data []byte) (*ParseResult, error) {
+	return ParseGen3SaveFormat(data, FormatAuto)
+}
+
+// ParseGen3SaveFormat parses a Gen 3 save, decoding its Pokemon and items as the given format
+func ParseGen3SaveFormat(data []byte, format Format) (*ParseResult, error) {
 	if len(data) < 0x20000 {
 		return nil, errors.New("save file too small")
 	}
@@ -670,82 +712,54 @@ func ParseGen3Save(data []byte) (*ParseResult, error) {
 	// There are 14 sectors per save slot (0-13)
 	// Save A: sectors 0x0000-0xDFFF, Save B: sectors 0xE000-0x1BFFF
 	// Each sector has a footer at offset 0xFF4 with section ID (2 bytes)
-	// Section 1 contains party data at offset 0x234 (count) and 0x238 (data)
+	// Sections 1-4 hold SaveBlock1 with the party, bag and flags; where depends on the game
 	// Sections 5-13 contain PC box storage
 
-	// Determine which save slot is more recent by checking the save index
-	// The save index is at offset 0xFFC in each sector's footer
-	// We need to find a sector with a valid signature (0x08012025) to get the save index
-	saveSlots := []int{0x0000, 0xE000} // Save A and Save B base addresses
-	saveIndexes := make([]uint32, 2)
-
-	for i, slotBase := range saveSlots {
-		// Check each sector to find one with a valid signature
-		for sectorIdx := 0; sectorIdx < 14; sectorIdx++ {
-			sectorBase := slotBase + (sectorIdx * 0x1000)
-			signatureOffset := sectorBase + 0xFF8
-			saveIndexOffset := sectorBase + 0xFFC
+	// Parse the newest slot, falling back to the older one if the newest is corrupt
+	integrity, slotBase := CheckIntegrity(data)
+	result.Integrity = integrity
 
-			if saveIndexOffset+4 > len(data) {
-				continue
-			}
+	// Ruby/Sapphire, Emerald and FireRed/LeafGreen store SaveBlock1 differently
+	l := detectLayout(data, slotBase)
+	result.Game = l.game
 
-			// Check for valid signature (0x08012025)
-			signature := binary.LittleEndian.Uint32(data[signatureOffset : signatureOffset+4])
-			if signature == 0x08012025 {
-				saveIndexes[i] = binary.LittleEndian.Uint32(data[saveIndexOffset : saveIndexOffset+4])
-				break
-			}
-		}
-	}
+	block1 := saveBlock1(data, slotBase)
+	pc := pcStorage(data, slotBase)
 
-	// Use the save slot with the higher save index (more recent)
-	var slotBase int
-	if saveIndexes[1] > saveIndexes[0] {
-		slotBase = 0xE000 // Save B is more recent
-	} else {
-		slotBase = 0x0000 // Save A is more recent (or equal)
+	// Vanilla games and ROM hack engines number species and items differently
+	p, ok := profiles[format]
+	if !ok {
+		p = detectProfile(l, block1, pc)
 	}
+	result.Format = p.format
+
+	if block1 != nil {
+		partyCount := int(block1[l.partyOffset])
+		if partyCount >= 1 && partyCount <= 6 {
+			// Parse each party Pokemon
+			partyDataOffset := l.partyOffset + 4
+			for i := 0; i < partyCount; i++ {
+				pokemonOffset := partyDataOffset + (i * 100)
+				pokemon := parsePokemon(block1[pokemonOffset:pokemonOffset+100], p)
+				if pokemon.SpeciesNum > 0 && pokemon.SpeciesNum <= 1025 {
+					result.Party = append(result.Party, pokemon)
+				}
+			}
 
-	// Find Section 1 (Team/Items) by checking sector footers
-	section1Offset := -1
-
-	for sectorIdx := 0; sectorIdx < 14; sectorIdx++ {
-		sectorBase := slotBase + (sectorIdx * 0x1000)
-		footerOffset := sectorBase + 0xFF4
+			// Parse box Pokemon from the same save slot
+			result.Boxes = parseBoxes(pc, p)
 
-		if footerOffset+4 > len(data) {
-			continue
-		}
+			// Parse bag items, decrypting quantities with the slot's security key
+			key := securityKey(data, slotBase, l, p)
+			result.Bag = parseBagItems(block1, l, p, uint16(key))
 
-		sectionID := binary.LittleEndian.Uint16(data[footerOffset : footerOffset+2])
-		if sectionID == 1 {
-			section1Offset = sectorBase
-			break
-		}
-	}
+			// Parse badges, defeated trainers and story flags
+			result.Progress = parseProgress(block1, l, p)
 
-	if section1Offset != -1 {
-		partyCountOffset := section1Offset + 0x234
-		partyDataOffset := section1Offset + 0x238
-
-		if partyDataOffset+600 <= len(data) {
-			partyCount := int(data[partyCountOffset])
-			if partyCount >= 1 && partyCount <= 6 {
-				// Parse each party Pokemon
-				for i := 0; i < partyCount; i++ {
-					pokemonOffset := partyDataOffset + (i * 100)
-					pokemon := parsePokemon(data[pokemonOffset : pokemonOffset+100])
-					if pokemon.SpeciesNum > 0 && pokemon.SpeciesNum <= 1025 {
-						result.Party = append(result.Party, pokemon)
-					}
-				}
-
-				// Parse box Pokemon from the same save slot
-				result.Boxes = parseBoxes(data, slotBase)
-
-				// Parse bag items (needs slotBase to find both section 0 and section 1)
-				result.Bag = parseBagItems(data, slotBase)
+			// Parse the trainer card and Pokedex
+			result.Trainer = parseTrainer(data, slotBase, block1, l, key)
+			if !p.movedPokedex {
+				result.Pokedex = parsePokedex(data, slotBase)
 			}
 		}
 	}
@@ -757,7 +771,8 @@ func ParseGen3Save(data []byte) (*ParseResult, error) {
 	return result, nil
 }
 
-func parsePokemon(data []byte) PartyPokemon {
+// parsePokemon parses a 100-byte party Pokemon record
+func parsePokemon(data []byte, p *profile) PartyPokemon {
 	if len(data) < 100 {
 		return PartyPokemon{}
 	}
@@ -778,82 +793,51 @@ func parsePokemon(data []byte) PartyPokemon {
 	// Calculate nature from personality
 	nature := natureNames[personality%25]
 
-	// Decrypt the data block (bytes 32-79)
-	encryptionKey := personality ^ otID
-	decryptedData := make([]byte, 48)
-	copy(decryptedData, data[32:80])
-
-	// XOR each 4-byte word
-	for i := 0; i < 48; i += 4 {
-		word := binary.LittleEndian.Uint32(decryptedData[i : i+4])
-		word ^= encryptionKey
-		binary.LittleEndian.PutUint32(decryptedData[i:i+4], word)
-	}
-
-	// Find substructure positions
-	order := substructOrder[personality%24]
-	typeToPos := make(map[int]int)
-	for pos, typ := range order {
-		typeToPos[typ] = pos
-	}
-
-	growthPos := typeToPos[0] * 12  // G = 0
-	attacksPos := typeToPos[1] * 12 // A = 1
-	miscPos := typeToPos[3] * 12    // M = 3
+	// Decrypt the data block (bytes 32-79) and find the substructure positions
+	decryptedData, positions, _ := decryptPokemon(data)
+	growthPos := positions[0]  // G = 0
+	attacksPos := positions[1] // A = 1
+	evsPos := positions[2]     // E = 2
+	miscPos := positions[3]    // M = 3
 
 	// Get species ID from Growth substructure (bytes 0-1)
 	rawSpeciesID := int(binary.LittleEndian.Uint16(decryptedData[growthPos : growthPos+2]))
-	speciesNum := rawSpeciesID & 0x7FF
-	if nationalDex, ok := expansionToNationalDex[speciesNum]; ok {
-		speciesNum = nationalDex
-	}
+	speciesNum := p.speciesNum(rawSpeciesID)
 
 	// Get item ID from Growth substructure (bytes 2-3)
 	rawItemID := int(binary.LittleEndian.Uint16(decryptedData[growthPos+2 : growthPos+4]))
-	itemNum := rawItemID
-	if showdownID, ok := expansionItemToShowdown[rawItemID]; ok {
-		itemNum = showdownID
-	}
+	itemNum := p.itemNum(rawItemID)
 
-	// Get extended nickname characters (11th and 12th) from Growth substruct
-	// nickname11: bytes 4-7, bits 21-28
-	// nickname12: bytes 10-11, bits 6-13
+	// Get experience from Growth substructure (bytes 4-7)
 	expData := binary.LittleEndian.Uint32(decryptedData[growthPos+4 : growthPos+8])
-	nickname11 := byte((expData >> 21) & 0xFF)
-	pokeballData := binary.LittleEndian.Uint16(decryptedData[growthPos+10 : growthPos+12])
-	nickname12 := byte((pokeballData >> 6) & 0xFF)
-
-	// Build full nickname (base + extended chars if present)
+	experience := expData
 	nickname := baseNickname
-	if nickname11 != 0xFF && nickname11 != 0 {
-		if char, ok := gen3CharTable[nickname11]; ok && char != 0 {
-			nickname += string(char)
-		}
-	}
-	if nickname12 != 0xFF && nickname12 != 0 {
-		if char, ok := gen3CharTable[nickname12]; ok && char != 0 {
-			nickname += string(char)
-		}
+	if p.packedGrowth {
+		// pokeemerald-expansion packs the extended nickname characters (11th and 12th) into Growth
+		// nickname11: bytes 4-7, bits 21-28 (experience keeps the lower 21 bits)
+		// nickname12: bytes 10-11, bits 6-13
+		experience = expData & 0x001FFFFF
+		pokeballData := binary.LittleEndian.Uint16(decryptedData[growthPos+10 : growthPos+12])
+		nickname += decodeExtendedNickname(byte(expData>>21), byte(pokeballData>>6))
 	}
 
 	// Get moves from Attacks substructure (bytes 0-7, 4 moves of 2 bytes each)
+	// Current PP is in bytes 8-11 and PP Ups are 2 bits per move in Growth byte 8
 	moveNums := make([]int, 0, 4)
+	pp := make([]int, 0, 4)
+	ppUps := make([]int, 0, 4)
+	ppBonuses := decryptedData[growthPos+8]
 	for i := 0; i < 4; i++ {
 		moveID := int(binary.LittleEndian.Uint16(decryptedData[attacksPos+i*2 : attacksPos+i*2+2]))
 		if moveID > 0 {
 			moveNums = append(moveNums, moveID)
+			pp = append(pp, int(decryptedData[attacksPos+8+i]))
+			ppUps = append(ppUps, int(ppBonuses>>(2*i)&0x3))
 		}
 	}
 
-	// Get ability slot from Misc substructure (bits 29-30 of bytes 8-11)
-	// In pokeemerald-expansion, abilityNum is a 2-bit field in the ribbon data:
-	// Bytes 8-11 contain: ribbons (29 bits) + abilityNum (2 bits) + fatefulEncounter (1 bit)
-	ribbonData := binary.LittleEndian.Uint32(decryptedData[miscPos+8 : miscPos+12])
-	abilitySlot := int((ribbonData >> 29) & 3) // 2 bits for ability index (0, 1, or 2 for hidden)
-
 	// Get EVs from EV/Condition substructure (E = 2)
 	// Bytes 0-5: HP, Attack, Defense, Speed, SpAtk, SpDef (1 byte each)
-	evsPos := typeToPos[2] * 12
 	evs := PokemonStats{
 		HP:      int(decryptedData[evsPos]),
 		Attack:  int(decryptedData[evsPos+1]),
@@ -876,9 +860,25 @@ func parsePokemon(data []byte) PartyPokemon {
 		SpDef:   int((ivData >> 25) & 0x1F),
 	}
 
+	// Get ability slot: vanilla has a single ability bit, bit 31 of the IV word
+	// In pokeemerald-expansion, abilityNum is a 2-bit field in the ribbon data (Misc bytes 8-11):
+	// ribbons (29 bits) + abilityNum (2 bits) + fatefulEncounter (1 bit)
+	abilitySlot := int(ivData >> 31)
+	if p.ribbonAbility {
+		ribbonData := binary.LittleEndian.Uint32(decryptedData[miscPos+8 : miscPos+12])
+		abilitySlot = int((ribbonData >> 29) & 3) // 0, 1, or 2 for hidden
+	}
+
 	// Get friendship from Growth substructure byte 9
 	friendship := int(decryptedData[growthPos+9])
 
+	// Get met location, met level, ball, origin game and OT from Misc substructure bytes 0-3
+	origin := parseOrigin(data, decryptedData, growthPos, miscPos, p)
+	pokerus := parsePokerus(decryptedData[miscPos])
+
+	// Get status condition from party data section (bytes 80-83)
+	status := parseStatus(binary.LittleEndian.Uint32(data[80:84]))
+
 	// Get stats from party data section (bytes 86-99)
 	// Party data: status(4), level(1), pokerus(1), currentHP(2), maxHP(2), atk(2), def(2), spe(2), spa(2), spd(2)
 	currentHP := int(binary.LittleEndian.Uint16(data[86:88]))
@@ -892,6 +892,8 @@ func parsePokemon(data []byte) PartyPokemon {
 	}
 
 	return PartyPokemon{
+		Personality: personality,
+		OTID:        otID,
 		Nickname:    nickname,
 		Level:       level,
 		SpeciesNum:  speciesNum,
@@ -902,11 +904,20 @@ func parsePokemon(data []byte) PartyPokemon {
 		IVs:         ivs,
 		EVs:         evs,
 		CurrentHP:   currentHP,
+		Status:      status,
+		PP:          pp,
+		PPUps:       ppUps,
 		AbilitySlot: abilitySlot,
+		Experience:  experience,
 		Friendship:  friendship,
+		Origin:      origin,
+		Pokerus:     pokerus,
+		Traits:      personalityTraits(personality, otID, speciesNum),
+		raw:         pk3Record(data[:32], decryptedData, data[80:100]),
 	}
 }
 
+// decodeGen3String decodes a Gen 3 string up to its terminator
 func decodeGen3String(data []byte) string {
 	result := make([]rune, 0, len(data))
 	for _, b := range data {
@@ -920,49 +931,30 @@ func decodeGen3String(data []byte) string {
 	return string(result)
 }
 
-// parseBoxes parses PC box storage from sections 5-13
-// Returns 14 boxes, each containing up to 30 Pokemon
-func parseBoxes(data []byte, slotBase int) [][]BoxPokemon {
-	boxes := make([][]BoxPokemon, 14)
-	for i := range boxes {
-		boxes[i] = []BoxPokemon{}
-	}
-
-	// Find sections 5-13 by checking sector footers
-	// These 9 sections form the PC storage buffer
-	sectionOffsets := make(map[int]int) // sectionID -> sector offset
-
-	for sectorIdx := 0; sectorIdx < 14; sectorIdx++ {
-		sectorBase := slotBase + (sectorIdx * 0x1000)
-		footerOffset := sectorBase + 0xFF4
-
-		if footerOffset+4 > len(data) {
+// decodeExtendedNickname decodes the 11th and 12th nickname characters of pokeemerald-expansion,
+// skipping terminators and unused characters
+func decodeExtendedNickname(chars ...byte) string {
+	result := ""
+	for _, c := range chars {
+		if c == 0xFF || c == 0 {
 			continue
 		}
-
-		sectionID := int(binary.LittleEndian.Uint16(data[footerOffset : footerOffset+2]))
-		if sectionID >= 5 && sectionID <= 13 {
-			sectionOffsets[sectionID] = sectorBase
+		if char, ok := gen3CharTable[c]; ok && char != 0 {
+			result += string(char)
 		}
 	}
+	return result
+}
 
-	// Check if we have all 9 sections
-	if len(sectionOffsets) < 9 {
-		return boxes // Return empty boxes if PC data incomplete
+// parseBoxes parses PC box storage, sections 5-13 joined by pcStorage
+// Returns 14 boxes, each containing up to 30 Pokemon
+func parseBoxes(pcBuffer []byte, p *profile) [][]BoxPokemon {
+	boxes := make([][]BoxPokemon, pcBoxes)
+	for i := range boxes {
+		boxes[i] = []BoxPokemon{}
 	}
-
-	// Assemble the PC buffer from sections 5-13
-	// Each section contributes 0xF80 bytes of data (before footer)
-	// Total: 9 * 0xF80 = 0x8B80 = 35,712 bytes
-	// But actual PC data is 33,744 bytes starting at offset 0
-	pcBuffer := make([]byte, 0, 9*0xF80)
-	for sectionID := 5; sectionID <= 13; sectionID++ {
-		sectorBase, ok := sectionOffsets[sectionID]
-		if !ok {
-			return boxes
-		}
-		// Copy the data portion of the sector (excluding footer)
-		pcBuffer = append(pcBuffer, data[sectorBase:sectorBase+0xF80]...)
+	if pcBuffer == nil {
+		return boxes // Return empty boxes if PC data incomplete
 	}
 
 	// PC buffer layout:
@@ -971,18 +963,17 @@ func parseBoxes(data []byte, slotBase int) [][]BoxPokemon {
 	// 14 boxes * 30 Pokemon * 80 bytes = 33,600 bytes
 
 	// Parse each box (14 boxes, 30 Pokemon each, 80 bytes per Pokemon)
-	const pokemonPerBox = 30
 	const pokemonSize = 80
 
-	for boxIdx := 0; boxIdx < 14; boxIdx++ {
+	for boxIdx := 0; boxIdx < pcBoxes; boxIdx++ {
 		for slotIdx := 0; slotIdx < pokemonPerBox; slotIdx++ {
 			// Calculate offset: 4 (current box) + (boxIdx * 30 + slotIdx) * 80
-			offset := 4 + (boxIdx*pokemonPerBox+slotIdx)*pokemonSize
+			offset := pcHeaderSize + (boxIdx*pokemonPerBox+slotIdx)*pokemonSize
 			if offset+pokemonSize > len(pcBuffer) {
 				break
 			}
 
-			pokemon := parseBoxPokemon(pcBuffer[offset : offset+pokemonSize])
+			pokemon := parseBoxPokemon(pcBuffer[offset:offset+pokemonSize], p)
 			if pokemon.SpeciesNum > 0 && pokemon.SpeciesNum <= 1500 {
 				boxes[boxIdx] = append(boxes[boxIdx], pokemon)
 			}
@@ -993,7 +984,7 @@ func parseBoxes(data []byte, slotBase int) [][]BoxPokemon {
 }
 
 // parseBoxPokemon parses an 80-byte box Pokemon record
-func parseBoxPokemon(data []byte) BoxPokemon {
+func parseBoxPokemon(data []byte, p *profile) BoxPokemon {
 	if len(data) < 80 {
 		return BoxPokemon{}
 	}
@@ -1013,64 +1004,31 @@ func parseBoxPokemon(data []byte) BoxPokemon {
 	// Calculate nature from personality
 	nature := natureNames[personality%25]
 
-	// Decrypt the data block (bytes 32-79)
-	encryptionKey := personality ^ otID
-	decryptedData := make([]byte, 48)
-	copy(decryptedData, data[32:80])
-
-	// XOR each 4-byte word
-	for i := 0; i < 48; i += 4 {
-		word := binary.LittleEndian.Uint32(decryptedData[i : i+4])
-		word ^= encryptionKey
-		binary.LittleEndian.PutUint32(decryptedData[i:i+4], word)
-	}
-
-	// Find substructure positions
-	order := substructOrder[personality%24]
-	typeToPos := make(map[int]int)
-	for pos, typ := range order {
-		typeToPos[typ] = pos
-	}
-
-	growthPos := typeToPos[0] * 12  // G = 0
-	attacksPos := typeToPos[1] * 12 // A = 1
-	miscPos := typeToPos[3] * 12    // M = 3
+	// Decrypt the data block (bytes 32-79) and find the substructure positions
+	decryptedData, positions, _ := decryptPokemon(data)
+	growthPos := positions[0]  // G = 0
+	attacksPos := positions[1] // A = 1
+	evsPos := positions[2]     // E = 2
+	miscPos := positions[3]    // M = 3
 
 	// Get species ID from Growth substructure (bytes 0-1)
 	rawSpeciesID := int(binary.LittleEndian.Uint16(decryptedData[growthPos : growthPos+2]))
-	speciesNum := rawSpeciesID & 0x7FF
-	if nationalDex, ok := expansionToNationalDex[speciesNum]; ok {
-		speciesNum = nationalDex
-	}
+	speciesNum := p.speciesNum(rawSpeciesID)
 
 	// Get item ID from Growth substructure (bytes 2-3)
 	rawItemID := int(binary.LittleEndian.Uint16(decryptedData[growthPos+2 : growthPos+4]))
-	itemNum := rawItemID
-	if showdownID, ok := expansionItemToShowdown[rawItemID]; ok {
-		itemNum = showdownID
-	}
+	itemNum := p.itemNum(rawItemID)
 
 	// Get experience from Growth substructure (bytes 4-7)
-	// In pokeemerald-expansion, bits 21-28 are used for nickname11, so mask to lower 21 bits
 	expData := binary.LittleEndian.Uint32(decryptedData[growthPos+4 : growthPos+8])
-	experience := expData & 0x001FFFFF // Lower 21 bits only (max ~2M exp, enough for level 100)
-
-	// Get extended nickname characters (11th and 12th) from Growth substruct
-	nickname11 := byte((expData >> 21) & 0xFF)
-	pokeballData := binary.LittleEndian.Uint16(decryptedData[growthPos+10 : growthPos+12])
-	nickname12 := byte((pokeballData >> 6) & 0xFF)
-
-	// Build full nickname (base + extended chars if present)
+	experience := expData
 	nickname := baseNickname
-	if nickname11 != 0xFF && nickname11 != 0 {
-		if char, ok := gen3CharTable[nickname11]; ok && char != 0 {
-			nickname += string(char)
-		}
-	}
-	if nickname12 != 0xFF && nickname12 != 0 {
-		if char, ok := gen3CharTable[nickname12]; ok && char != 0 {
-			nickname += string(char)
-		}
+	if p.packedGrowth {
+		// In pokeemerald-expansion, bits 21-28 are used for nickname11, so mask to lower 21 bits
+		// and read the 12th character from bits 6-13 of bytes 10-11
+		experience = expData & 0x001FFFFF
+		pokeballData := binary.LittleEndian.Uint16(decryptedData[growthPos+10 : growthPos+12])
+		nickname += decodeExtendedNickname(byte(expData>>21), byte(pokeballData>>6))
 	}
 
 	// Get moves from Attacks substructure (bytes 0-7, 4 moves of 2 bytes each)
@@ -1082,12 +1040,7 @@ func parseBoxPokemon(data []byte) BoxPokemon {
 		}
 	}
 
-	// Get ability slot from Misc substructure
-	ribbonData := binary.LittleEndian.Uint32(decryptedData[miscPos+8 : miscPos+12])
-	abilitySlot := int((ribbonData >> 29) & 3)
-
 	// Get EVs from EV/Condition substructure (E = 2)
-	evsPos := typeToPos[2] * 12
 	evs := PokemonStats{
 		HP:      int(decryptedData[evsPos]),
 		Attack:  int(decryptedData[evsPos+1]),
@@ -1108,14 +1061,29 @@ func parseBoxPokemon(data []byte) BoxPokemon {
 		SpDef:   int((ivData >> 25) & 0x1F),
 	}
 
+	// Get ability slot: vanilla has a single ability bit, bit 31 of the IV word
+	// In pokeemerald-expansion, abilityNum is a 2-bit field in the ribbon data (Misc bytes 8-11):
+	// ribbons (29 bits) + abilityNum (2 bits) + fatefulEncounter (1 bit)
+	abilitySlot := int(ivData >> 31)
+	if p.ribbonAbility {
+		ribbonData := binary.LittleEndian.Uint32(decryptedData[miscPos+8 : miscPos+12])
+		abilitySlot = int((ribbonData >> 29) & 3) // 0, 1, or 2 for hidden
+	}
+
 	// Get friendship from Growth substructure byte 9
 	friendship := int(decryptedData[growthPos+9])
 
-	// Calculate level from experience (using Medium Fast as approximation)
-	// Medium Fast: EXP = n^3 where n is level
-	level := expToLevel(experience)
+	// Get met location, met level, ball, origin game and OT from Misc substructure bytes 0-3
+	origin := parseOrigin(data, decryptedData, growthPos, miscPos, p)
+	pokerus := parsePokerus(decryptedData[miscPos])
+
+	// Box Pokemon don't store their level; estimate it with the Medium Fast curve until
+	// ApplyGrowthRates corrects it with the species' growth rate
+	level := GrowthMediumFast.LevelForExp(experience)
 
 	return BoxPokemon{
+		Personality: personality,
+		OTID:        otID,
 		Nickname:    nickname,
 		Level:       level,
 		SpeciesNum:  speciesNum,
@@ -1127,33 +1095,18 @@ func parseBoxPokemon(data []byte) BoxPokemon {
 		AbilitySlot: abilitySlot,
 		Experience:  experience,
 		Friendship:  friendship,
+		Origin:      origin,
+		Pokerus:     pokerus,
+		Traits:      personalityTraits(personality, otID, speciesNum),
+		raw:         pk3Record(data[:32], decryptedData),
 	}
 }
 
-// expToLevel converts experience to level using Medium Fast curve as approximation
-// Medium Fast: EXP = n^3
-func expToLevel(exp uint32) int {
-	// Binary search for the level
-	for level := 100; level >= 1; level-- {
-		requiredExp := uint32(level * level * level)
-		if exp >= requiredExp {
-			return level
-		}
-	}
-	return 1
-}
-
-// parseBagItems parses all bag pockets from Section 1
-// Emerald bag pocket offsets (relative to section start):
-// - PC Items: 0x0498, 50 slots
-// - Items: 0x0560, 30 slots
-// - Key Items: 0x05D8, 30 slots
-// - Poke Balls: 0x0650, 16 slots
-// - TMs/HMs: 0x0690, 64 slots
-// - Berries: 0x0790, 46 slots
-// Each slot is 4 bytes: 2 bytes item ID, 2 bytes encrypted quantity
-// pokeemerald-expansion encrypts quantities with a key stored in section 0 at offset 0x44
-func parseBagItems(data []byte, slotBase int) *BagPockets {
+// parseBagItems parses all bag pockets from SaveBlock1, at the pocket offsets of the layout
+// Each slot is 4 bytes: 2 bytes item ID, 2 bytes quantity
+// Emerald and FireRed/LeafGreen XOR bag quantities with the low half of the security key;
+// Ruby/Sapphire store them as-is and pass a key of 0. PC item quantities are never encrypted.
+func parseBagItems(block1 []byte, l *layout, p *profile, encryptionKey uint16) *BagPockets {
 	bag := &BagPockets{
 		PCItems:   []BagItem{},
 		Items:     []BagItem{},
@@ -1163,46 +1116,13 @@ func parseBagItems(data []byte, slotBase int) *BagPockets {
 		Berries:   []BagItem{},
 	}
 
-	// Find section 0 and section 1 offsets
-	section0Offset := -1
-	section1Offset := -1
-	for sectorIdx := 0; sectorIdx < 14; sectorIdx++ {
-		sectorBase := slotBase + (sectorIdx * 0x1000)
-		footerOffset := sectorBase + 0xFF4
-		if footerOffset+4 > len(data) {
-			continue
-		}
-		sectionID := int(binary.LittleEndian.Uint16(data[footerOffset : footerOffset+2]))
-		if sectionID == 0 {
-			section0Offset = sectorBase
-		} else if sectionID == 1 {
-			section1Offset = sectorBase
-		}
-	}
-
-	if section0Offset == -1 || section1Offset == -1 {
-		return bag
-	}
-
-	// Get the encryption key from section 0 at offset 0x44
-	// pokeemerald-expansion uses this to encrypt bag item quantities
-	keyOffset := section0Offset + 0x44
-	encryptionKey := uint16(0)
-	if keyOffset+2 <= len(data) {
-		encryptionKey = binary.LittleEndian.Uint16(data[keyOffset : keyOffset+2])
-	}
-
 	// Helper function to parse a pocket
-	parsePocket := func(offset, maxSlots int) []BagItem {
+	parsePocket := func(pk pocket, key uint16) []BagItem {
 		items := []BagItem{}
-		for i := 0; i < maxSlots; i++ {
-			slotOffset := section1Offset + offset + (i * 4)
-			if slotOffset+4 > len(data) {
-				break
-			}
-
-			itemID := int(binary.LittleEndian.Uint16(data[slotOffset : slotOffset+2]))
-			encryptedQty := binary.LittleEndian.Uint16(data[slotOffset+2 : slotOffset+4])
+		for i := 0; i < pk.slots; i++ {
+			slotOffset := pk.offset + (i * 4)
+			itemID := int(binary.LittleEndian.Uint16(block1[slotOffset : slotOffset+2]))
+			encryptedQty := binary.LittleEndian.Uint16(block1[slotOffset+2 : slotOffset+4])
 
 			// Skip empty slots
 			if itemID == 0 {
@@ -1210,17 +1130,17 @@ func parseBagItems(data []byte, slotBase int) *BagPockets {
 			}
 
 			// Decrypt quantity by XORing with the encryption key
-			quantity := int(encryptedQty ^ encryptionKey)
+			quantity := int(encryptedQty ^ key)
 
 			// Skip if quantity is invalid (0 or unreasonably high)
 			if quantity <= 0 || quantity > 999 {
 				continue
 			}
 
-			// Convert expansion item ID to Showdown ID
-			showdownID := itemID
-			if mappedID, ok := expansionItemToShowdown[itemID]; ok {
-				showdownID = mappedID
+			// Skip items with no Showdown equivalent, like vanilla key items
+			showdownID := p.itemNum(itemID)
+			if showdownID == 0 {
+				continue
 			}
 
 			items = append(items, BagItem{
@@ -1231,12 +1151,12 @@ func parseBagItems(data []byte, slotBase int) *BagPockets {
 		return items
 	}
 
-	bag.PCItems = parsePocket(0x0498, 50)
-	bag.Items = parsePocket(0x0560, 30)
-	bag.KeyItems = parsePocket(0x05D8, 30)
-	bag.PokeBalls = parsePocket(0x0650, 16)
-	bag.TMsHMs = parsePocket(0x0690, 64)
-	bag.Berries = parsePocket(0x0790, 46)
+	bag.PCItems = parsePocket(l.pcItems, 0)
+	bag.Items = parsePocket(l.items, encryptionKey)
+	bag.KeyItems = parsePocket(l.keyItems, encryptionKey)
+	bag.PokeBalls = parsePocket(l.pokeBalls, encryptionKey)
+	bag.TMsHMs = parsePocket(l.tmsHMs, encryptionKey)
+	bag.Berries = parsePocket(l.berries, encryptionKey)
 
 	return bag
 }
@@ -0,0 +1,67 @@
+package savefile
+
+// National Dex numbers of the species with personality-dependent traits
+const (
+	speciesUnown   = 201
+	speciesWurmple = 265
+	speciesSpinda  = 327
+)
+
+// Traits are the characteristics derived from a Pokemon's personality value
+type Traits struct {
+	Shiny            bool         `json:"shiny"`
+	UnownLetter      string       `json:"unownLetter,omitempty"`
+	SpindaSpots      []SpindaSpot `json:"spindaSpots,omitempty"`
+	WurmpleEvolution string       `json:"wurmpleEvolution,omitempty"` // "Silcoon" or "Cascoon"
+}
+
+// SpindaSpot is the offset of one of Spinda's four spots, each 0-15
+type SpindaSpot struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// unownLetters are Unown's forms in personality order
+const unownLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ!?"
+
+// personalityTraits derives shininess and species-specific forms from a personality value
+func personalityTraits(personality, otID uint32, speciesNum int) Traits {
+	traits := Traits{Shiny: IsShiny(personality, otID)}
+
+	switch speciesNum {
+	case speciesUnown:
+		// Two bits from each byte of the personality value
+		letter := (personality>>18)&0xC0 | (personality>>12)&0x30 | (personality>>6)&0x0C | personality&0x03
+		traits.UnownLetter = string(unownLetters[letter%uint32(len(unownLetters))])
+	case speciesSpinda:
+		// One spot per byte, starting from the low byte: low nibble X, high nibble Y
+		traits.SpindaSpots = make([]SpindaSpot, 4)
+		for i := range traits.SpindaSpots {
+			b := byte(personality >> (8 * i))
+			traits.SpindaSpots[i] = SpindaSpot{X: int(b & 0xF), Y: int(b >> 4)}
+		}
+	case speciesWurmple:
+		if (personality>>16)%10 < 5 {
+			traits.WurmpleEvolution = "Silcoon"
+		} else {
+			traits.WurmpleEvolution = "Cascoon"
+		}
+	}
+
+	return traits
+}
+
+// IsShiny returns true if the personality value and OT ID (trainer ID and secret ID) make a
+// Pokemon shiny: the XOR of their four 16-bit halves is below 8
+func IsShiny(personality, otID uint32) bool {
+	return (otID>>16)^(otID&0xFFFF)^(personality>>16)^(personality&0xFFFF) < 8
+}
+
+// GenderFromPersonality returns "M" or "F" for a species that is femaleRatio female (0-1)
+// The low byte of the personality value is compared against the species' threshold
+func GenderFromPersonality(personality uint32, femaleRatio float64) string {
+	if int(personality&0xFF) < int(femaleRatio*255) {
+		return "F"
+	}
+	return "M"
+}
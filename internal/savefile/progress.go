@@ -0,0 +1,68 @@
+package savefile
+
+import "encoding/binary"
+
+// Progress is how far into the game a save is, read from its event flags
+type Progress struct {
+	Badges           []string `json:"badges"`           // Badges owned, in gym order
+	BadgeCount       int      `json:"badgeCount"`       // Number of badges owned
+	DefeatedTrainers []int    `json:"defeatedTrainers"` // In-game trainer IDs whose defeated flag is set
+	StoryFlags       []string `json:"storyFlags"`       // Key story events reached, see layout.storyFlags
+	HMs              []string `json:"hms"`              // HM moves obtained
+}
+
+// hmMoves are the moves taught by HM01-HM08
+var hmMoves = []string{"Cut", "Fly", "Surf", "Strength", "Flash", "Rock Smash", "Waterfall", "Dive"}
+
+// parseProgress reads the event flags and the HMs in the bag from SaveBlock1
+// HMs are left out for formats whose HM item IDs aren't known
+func parseProgress(block1 []byte, l *layout, p *profile) *Progress {
+	flags := block1[l.flagsOffset : l.flagsOffset+l.flagsSize]
+	isSet := func(flag int) bool {
+		return flags[flag/8]&(1<<(flag%8)) != 0
+	}
+
+	progress := &Progress{
+		Badges:           []string{},
+		DefeatedTrainers: []int{},
+		StoryFlags:       []string{},
+		HMs:              []string{},
+	}
+	for i, name := range l.badges {
+		if isSet(l.badgeFlagBase + i) {
+			progress.Badges = append(progress.Badges, name)
+		}
+	}
+	progress.BadgeCount = len(progress.Badges)
+
+	// Trainer 0 is TRAINER_NONE
+	for id := 1; id < l.maxTrainerID; id++ {
+		if isSet(l.trainerFlagBase + id) {
+			progress.DefeatedTrainers = append(progress.DefeatedTrainers, id)
+		}
+	}
+
+	for _, f := range l.storyFlags {
+		if isSet(f.flag) {
+			progress.StoryFlags = append(progress.StoryFlags, f.name)
+		}
+	}
+
+	// HMs can't be tossed, so one in the bag means it was obtained
+	// Item IDs are read raw because the bag parser maps them to Showdown numbers
+	owned := make([]bool, len(hmMoves))
+	for i := 0; p.hmItemBase > 0 && i < l.tmsHMs.slots; i++ {
+		slot := l.tmsHMs.offset + i*4
+		item := int(binary.LittleEndian.Uint16(block1[slot : slot+2]))
+		if item >= p.hmItemBase && item < p.hmItemBase+len(hmMoves) {
+			owned[item-p.hmItemBase] = true
+		}
+	}
+	for i, move := range hmMoves {
+		if owned[i] {
+			progress.HMs = append(progress.HMs, move)
+		}
+	}
+
+	return progress
+}
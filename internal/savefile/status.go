@@ -0,0 +1,32 @@
+package savefile
+
+// Status is a party Pokemon's non-volatile status condition
+type Status struct {
+	Condition  string `json:"condition,omitempty"`  // Showdown status ID: slp, psn, brn, frz, par or tox
+	SleepTurns int    `json:"sleepTurns,omitempty"` // Turns left asleep
+}
+
+// parseStatus decodes the status condition word at bytes 80-83 of a party Pokemon
+// Bits 0-2 are the sleep counter, then one bit each for poison, burn, freeze, paralysis and toxic
+func parseStatus(word uint32) Status {
+	switch {
+	case word&0x7 != 0:
+		return Status{Condition: "slp", SleepTurns: int(word & 0x7)}
+	case word&0x80 != 0:
+		return Status{Condition: "tox"}
+	case word&0x08 != 0:
+		return Status{Condition: "psn"}
+	case word&0x10 != 0:
+		return Status{Condition: "brn"}
+	case word&0x20 != 0:
+		return Status{Condition: "frz"}
+	case word&0x40 != 0:
+		return Status{Condition: "par"}
+	}
+	return Status{}
+}
+
+// MaxPP returns a move's maximum PP after PP Ups: each one adds a fifth of the base PP
+func MaxPP(basePP, ppUps int) int {
+	return basePP + basePP/5*ppUps
+}
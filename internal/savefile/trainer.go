@@ -0,0 +1,98 @@
+package savefile
+
+import "encoding/binary"
+
+// Offsets in section 0 (SaveBlock2), which are the same in every Gen 3 game
+const (
+	trainerNameOffset   = 0x00 // 7 characters + terminator
+	trainerGenderOffset = 0x08
+	trainerIDOffset     = 0x0A // Public ID (low 16 bits) and secret ID (high 16 bits)
+	playTimeOffset      = 0x0E // Hours (2 bytes), minutes, seconds
+	pokedexOwnedOffset  = 0x28
+	pokedexSeenOffset   = 0x5C
+	pokedexFlagsSize    = 52
+	pokedexSize         = 386 // National Dex entries in Gen 3
+)
+
+// PlayTime is the in-game clock
+type PlayTime struct {
+	Hours   int `json:"hours"`
+	Minutes int `json:"minutes"`
+	Seconds int `json:"seconds"`
+}
+
+// Trainer is the player's trainer card
+type Trainer struct {
+	Name     string   `json:"name"`
+	Gender   string   `json:"gender"` // "male" or "female"
+	ID       uint16   `json:"id"`     // Public trainer ID shown in game
+	SecretID uint16   `json:"secretId"`
+	PlayTime PlayTime `json:"playTime"`
+	Money    uint32   `json:"money"`
+	Coins    uint16   `json:"coins"`
+}
+
+// Pokedex holds the National Dex numbers the player has owned and seen
+type Pokedex struct {
+	Owned []int `json:"owned"`
+	Seen  []int `json:"seen"`
+}
+
+// parseTrainer reads the trainer card from section 0 and money and coins from SaveBlock1
+// Returns nil when section 0 is missing
+func parseTrainer(data []byte, slotBase int, block1 []byte, l *layout, key uint32) *Trainer {
+	section0 := sectionOffset(data, slotBase, 0)
+	if section0 == -1 {
+		return nil
+	}
+	block := data[section0 : section0+sectorSize]
+
+	trainerID := binary.LittleEndian.Uint32(block[trainerIDOffset : trainerIDOffset+4])
+	trainer := &Trainer{
+		Name:     decodeGen3String(block[trainerNameOffset : trainerNameOffset+8]),
+		Gender:   "male",
+		ID:       uint16(trainerID),
+		SecretID: uint16(trainerID >> 16),
+		PlayTime: PlayTime{
+			Hours:   int(binary.LittleEndian.Uint16(block[playTimeOffset : playTimeOffset+2])),
+			Minutes: int(block[playTimeOffset+2]),
+			Seconds: int(block[playTimeOffset+3]),
+		},
+	}
+	if block[trainerGenderOffset] == 1 {
+		trainer.Gender = "female"
+	}
+
+	// Money and coins are XORed with the same key as bag quantities
+	trainer.Money = binary.LittleEndian.Uint32(block1[l.moneyOffset:l.moneyOffset+4]) ^ key
+	trainer.Coins = binary.LittleEndian.Uint16(block1[l.coinsOffset:l.coinsOffset+2]) ^ uint16(key)
+
+	return trainer
+}
+
+// parsePokedex reads the owned and seen flags from section 0
+// Returns nil when section 0 is missing
+func parsePokedex(data []byte, slotBase int) *Pokedex {
+	section0 := sectionOffset(data, slotBase, 0)
+	if section0 == -1 {
+		return nil
+	}
+	owned := data[section0+pokedexOwnedOffset : section0+pokedexOwnedOffset+pokedexFlagsSize]
+	seen := data[section0+pokedexSeenOffset : section0+pokedexSeenOffset+pokedexFlagsSize]
+
+	return &Pokedex{
+		Owned: dexNumbers(owned),
+		Seen:  dexNumbers(seen),
+	}
+}
+
+// dexNumbers lists the National Dex numbers set in a Pokedex bitfield (bit 0 is #001)
+func dexNumbers(flags []byte) []int {
+	nums := []int{}
+	for num := 1; num <= pokedexSize && num <= len(flags)*8; num++ {
+		if flags[(num-1)/8]&(1<<((num-1)%8)) != 0 {
+			nums = append(nums, num)
+		}
+	}
+	return nums
+}
@@ -0,0 +1,212 @@
+package savefile
+
+// gen3ToNationalDex maps the internal species IDs of the vanilla Gen 3 games to National Dex numbers
+// IDs 1-251 are National Dex numbers already; 252-276 are unused placeholders and the
+// Hoenn species follow from 277 in an order of their own
+var gen3ToNationalDex = map[int]int{
+	277: 252, 278: 253, 279: 254, 280: 255, 281: 256, 282: 257,
+	283: 258, 284: 259, 285: 260, 286: 261, 287: 262, 288: 263,
+	289: 264, 290: 265, 291: 266, 292: 267, 293: 268, 294: 269,
+	295: 270, 296: 271, 297: 272, 298: 273, 299: 274, 300: 275,
+	301: 290, 302: 291, 303: 292, 304: 276, 305: 277, 306: 285,
+	307: 286, 308: 327, 309: 278, 310: 279, 311: 283, 312: 284,
+	313: 320, 314: 321, 315: 300, 316: 301, 317: 352, 318: 343,
+	319: 344, 320: 299, 321: 324, 322: 302, 323: 339, 324: 340,
+	325: 370, 326: 341, 327: 342, 328: 349, 329: 350, 330: 318,
+	331: 319, 332: 328, 333: 329, 334: 330, 335: 296, 336: 297,
+	337: 309, 338: 310, 339: 322, 340: 323, 341: 363, 342: 364,
+	343: 365, 344: 331, 345: 332, 346: 361, 347: 362, 348: 337,
+	349: 338, 350: 298, 351: 325, 352: 326, 353: 311, 354: 312,
+	355: 303, 356: 307, 357: 308, 358: 333, 359: 334, 360: 360,
+	361: 355, 362: 356, 363: 315, 364: 287, 365: 288, 366: 289,
+	367: 316, 368: 317, 369: 357, 370: 293, 371: 294, 372: 295,
+	373: 366, 374: 367, 375: 368, 376: 359, 377: 353, 378: 354,
+	379: 336, 380: 335, 381: 369, 382: 304, 383: 305, 384: 306,
+	385: 351, 386: 313, 387: 314, 388: 345, 389: 346, 390: 347,
+	391: 348, 392: 280, 393: 281, 394: 282, 395: 371, 396: 372,
+	397: 373, 398: 374, 399: 375, 400: 376, 401: 377, 402: 378,
+	403: 379, 404: 382, 405: 383, 406: 384, 407: 380, 408: 381,
+	409: 385, 410: 386, 411: 358,
+}
+
+// gen3ItemToShowdown maps the item IDs of the vanilla Gen 3 games to Showdown item numbers
+// Key items, TMs, HMs and mail have no Showdown equivalent and are left out
+var gen3ItemToShowdown = map[int]int{
+	1:   276,   // Master Ball
+	2:   521,   // Ultra Ball
+	3:   174,   // Great Ball
+	4:   345,   // Poke Ball
+	5:   425,   // Safari Ball
+	6:   304,   // Net Ball
+	7:   101,   // Dive Ball
+	8:   303,   // Nest Ball
+	9:   401,   // Repeat Ball
+	10:  494,   // Timer Ball
+	11:  266,   // Luxury Ball
+	12:  363,   // Premier Ball
+	13:  10003, // Potion
+	14:  18,    // Antidote
+	15:  19,    // Burn Heal
+	16:  20,    // Ice Heal
+	17:  10004, // Awakening
+	18:  10005, // Paralyze Heal
+	19:  23,    // Full Restore
+	20:  24,    // Max Potion
+	21:  25,    // Hyper Potion
+	22:  26,    // Super Potion
+	23:  10006, // Full Heal
+	24:  28,    // Revive
+	25:  10007, // Max Revive
+	26:  30,    // Fresh Water
+	27:  10008, // Soda Pop
+	28:  10009, // Lemonade
+	29:  33,    // Moomoo Milk
+	30:  10010, // Energy Powder
+	31:  10011, // Energy Root
+	32:  36,    // Heal Powder
+	33:  37,    // Revival Herb
+	34:  38,    // Ether
+	35:  39,    // Max Ether
+	36:  40,    // Elixir
+	37:  10012, // Max Elixir
+	38:  42,    // Lava Cookie
+	39:  10013, // Blue Flute
+	40:  10014, // Yellow Flute
+	41:  10015, // Red Flute
+	42:  10016, // Black Flute
+	43:  10017, // White Flute
+	44:  22,    // Berry Juice
+	45:  10018, // Sacred Ash
+	46:  10019, // Shoal Salt
+	47:  10020, // Shoal Shell
+	48:  10021, // Red Shard
+	49:  73,    // Blue Shard
+	50:  74,    // Yellow Shard
+	51:  75,    // Green Shard
+	63:  45,    // HP Up
+	64:  46,    // Protein
+	65:  47,    // Iron
+	66:  48,    // Carbos
+	67:  49,    // Calcium
+	68:  50,    // Rare Candy
+	69:  10022, // PP Up
+	70:  52,    // Zinc
+	71:  10023, // PP Max
+	73:  55,    // Guard Spec.
+	74:  56,    // Dire Hit
+	75:  57,    // X Attack
+	76:  58,    // X Defense
+	77:  59,    // X Speed
+	78:  10024, // X Accuracy
+	79:  10025, // X Sp. Atk
+	80:  10026, // Poké Doll
+	81:  10027, // Fluffy Tail
+	83:  10028, // Super Repel
+	84:  77,    // Max Repel
+	85:  10029, // Escape Rope
+	86:  79,    // Repel
+	93:  480,   // Sun Stone
+	94:  295,   // Moon Stone
+	95:  142,   // Fire Stone
+	96:  492,   // Thunder Stone
+	97:  529,   // Water Stone
+	98:  241,   // Leaf Stone
+	103: 10030, // Tiny Mushroom
+	104: 87,    // Big Mushroom
+	106: 10031, // Pearl
+	107: 10032, // Big Pearl
+	108: 90,    // Stardust
+	109: 91,    // Star Piece
+	110: 10033, // Nugget
+	111: 10034, // Heart Scale
+	133: 63,    // Cheri Berry
+	134: 65,    // Chesto Berry
+	135: 333,   // Pecha Berry
+	136: 381,   // Rawst Berry
+	137: 13,    // Aspear Berry
+	138: 244,   // Leppa Berry
+	139: 319,   // Oran Berry
+	140: 334,   // Persim Berry
+	141: 262,   // Lum Berry
+	142: 448,   // Sitrus Berry
+	143: 140,   // Figy Berry
+	144: 538,   // Wiki Berry
+	145: 274,   // Mago Berry
+	146: 5,     // Aguav Berry
+	147: 217,   // Iapapa Berry
+	148: 384,   // Razz Berry
+	149: 44,    // Bluk Berry
+	150: 302,   // Nanab Berry
+	151: 533,   // Wepear Berry
+	152: 337,   // Pinap Berry
+	153: 351,   // Pomeg Berry
+	154: 235,   // Kelpsy Berry
+	155: 371,   // Qualot Berry
+	156: 213,   // Hondew Berry
+	157: 178,   // Grepa Berry
+	158: 486,   // Tamato Berry
+	159: 81,    // Cornn Berry
+	160: 275,   // Magost Berry
+	161: 375,   // Rabuta Berry
+	162: 306,   // Nomel Berry
+	163: 462,   // Spelon Berry
+	164: 323,   // Pamtre Berry
+	165: 530,   // Watmel Berry
+	166: 114,   // Durin Berry
+	167: 21,    // Belue Berry
+	168: 248,   // Liechi Berry
+	169: 158,   // Ganlon Berry
+	170: 426,   // Salac Berry
+	171: 335,   // Petaya Berry
+	172: 10,    // Apicot Berry
+	173: 238,   // Lansat Berry
+	174: 472,   // Starf Berry
+	175: 124,   // Enigma Berry
+	179: 51,    // Bright Powder
+	180: 535,   // White Herb
+	181: 269,   // Macho Brace
+	182: 10035, // Exp. Share
+	183: 10036, // Quick Claw
+	184: 10037, // Soothe Bell
+	185: 285,   // Mental Herb
+	186: 68,    // Choice Band
+	187: 236,   // King's Rock
+	188: 447,   // Silver Powder
+	189: 200,   // Amulet Coin
+	190: 201,   // Cleanse Tag
+	191: 459,   // Soul Dew
+	192: 94,    // Deep Sea Tooth
+	193: 93,    // Deep Sea Scale
+	194: 205,   // Smoke Ball
+	195: 206,   // Everstone
+	196: 150,   // Focus Band
+	197: 208,   // Lucky Egg
+	198: 429,   // Scope Lens
+	199: 286,   // Metal Coat
+	200: 242,   // Leftovers
+	201: 108,   // Dragon Scale
+	202: 251,   // Light Ball
+	203: 456,   // Soft Sand
+	204: 187,   // Hard Stone
+	205: 10038, // Miracle Seed
+	206: 35,    // Black Glasses
+	207: 32,    // Black Belt
+	208: 273,   // Magnet
+	209: 300,   // Mystic Water
+	210: 436,   // Sharp Beak
+	211: 343,   // Poison Barb
+	212: 305,   // Never-Melt Ice
+	213: 461,   // Spell Tag
+	214: 520,   // Twisted Spoon
+	215: 61,    // Charcoal
+	216: 106,   // Dragon Fang
+	217: 444,   // Silk Scarf
+	218: 523,   // Up-Grade
+	219: 438,   // Shell Bell
+	220: 430,   // Sea Incense
+	221: 240,   // Lax Incense
+	222: 261,   // Lucky Punch
+	223: 10039, // Metal Powder
+	224: 491,   // Thick Club
+	225: 10040, // Stick
+}
@@ -0,0 +1,156 @@
+#!/usr/bin/env python3
+"""
+Generate data/encounters/emerald.json from pokeemerald's wild_encounters.json.
+
+Tables keep pokeemerald's slot order, so slot N of a method is the game's slot N.
+Pass a path to read a local wild_encounters.json instead of fetching it, e.g. a
+hack's own copy, and --base emerald to write an overlay with only the tables the
+hack changes.
+"""
+
+import argparse
+import json
+import re
+import urllib.request
+
+WILD_ENCOUNTERS_URL = "https://raw.githubusercontent.com/pret/pokeemerald/master/src/data/wild_encounters.json"
+
+# Slot rates from the gWildMonHeaders fields, in slot order
+RATES = {
+    "grass": [20, 20, 10, 10, 10, 10, 5, 5, 4, 4, 1, 1],
+    "surf": [60, 30, 5, 4, 1],
+    "rockSmash": [60, 30, 5, 4, 1],
+    "oldRod": [70, 30],
+    "goodRod": [60, 20, 20],
+    "superRod": [40, 40, 15, 4, 1],
+}
+
+# Fishing slots by rod, from the fishing_mons groups
+RODS = {"oldRod": range(0, 2), "goodRod": range(2, 5), "superRod": range(5, 10)}
+
+# Maps whose location ID or name isn't derived from the map constant
+LOCATIONS = {
+    "MAP_GRANITE_CAVE_STEVENS_ROOM": ("granitecavestevensroom", "Granite Cave Steven's Room"),
+    "MAP_NEW_MAUVILLE_INSIDE": ("newmauville", "New Mauville"),
+    "MAP_METEOR_FALLS_1F_1R": ("meteorfalls1f1r", "Meteor Falls"),
+    "MAP_METEOR_FALLS_1F_2R": ("meteorfalls1f2r", "Meteor Falls 1F Back"),
+    "MAP_METEOR_FALLS_B1F_1R": ("meteorfallsb1f1r", "Meteor Falls B1F"),
+    "MAP_METEOR_FALLS_B1F_2R": ("meteorfallsb1f2r", "Meteor Falls B1F Back"),
+    "MAP_SHOAL_CAVE_LOW_TIDE_ENTRANCE_ROOM": ("shoalcave", "Shoal Cave"),
+    "MAP_SHOAL_CAVE_LOW_TIDE_ICE_ROOM": ("shoalcavelowtideicyroom", "Shoal Cave Ice Room"),
+    "MAP_SEAFLOOR_CAVERN_ENTRANCE": ("seafloorcavernentrance", "Seafloor Cavern Entrance"),
+    "MAP_SEAFLOOR_CAVERN_ROOM1": ("seafloorcavern", "Seafloor Cavern"),
+    "MAP_CAVE_OF_ORIGIN_ENTRANCE": ("caveoforiginentrance", "Cave of Origin Entrance"),
+    "MAP_CAVE_OF_ORIGIN_1F": ("caveoforigin", "Cave of Origin"),
+    "MAP_ABANDONED_SHIP_HIDDEN_FLOOR_CORRIDORS": ("abandonedship", "Abandoned Ship"),
+    "MAP_UNDERWATER_ROUTE124": ("underwaterroute124", "Route 124 Underwater"),
+    "MAP_UNDERWATER_ROUTE126": ("underwaterroute126", "Route 126 Underwater"),
+    "MAP_ROUTE130": ("route130", "Route 130 (Mirage Island)"),
+}
+
+# Species whose display name isn't the title-cased constant
+SPECIES = {
+    "NIDORAN_F": "Nidoran-F",
+    "NIDORAN_M": "Nidoran-M",
+    "FARFETCHD": "Farfetch’d",
+    "MR_MIME": "Mr. Mime",
+    "HO_OH": "Ho-Oh",
+}
+
+def fetch_url(url):
+    with urllib.request.urlopen(url) as response:
+        return response.read().decode('utf-8')
+
+def location(map_const):
+    """Convert MAP_GRANITE_CAVE_1F to (granitecave1f, Granite Cave 1F)"""
+    if map_const in LOCATIONS:
+        return LOCATIONS[map_const]
+    words = map_const[len("MAP_"):].split("_")
+    name = " ".join(w if re.fullmatch(r"B?\d+F", w) else w.title() for w in words)
+    name = re.sub(r"^Route(\d+)", r"Route \1", name).replace("Mt ", "Mt. ")
+    return "".join(words).lower(), name
+
+def species_name(const):
+    """Convert SPECIES_WURMPLE to Wurmple"""
+    name = const[len("SPECIES_"):]
+    return SPECIES.get(name, name.replace("_", " ").title())
+
+def slots(mons, method, indices=None):
+    rates = RATES[method]
+    if indices is None:
+        indices = range(len(mons))
+    picked = [mons[i] for i in indices]
+    if len(picked) != len(rates):
+        raise ValueError(f"{method}: expected {len(rates)} slots, got {len(picked)}")
+    return [{
+        "species": species_name(mon["species"]),
+        "minLevel": mon["min_level"],
+        "maxLevel": mon["max_level"],
+        "rate": rate,
+    } for mon, rate in zip(picked, rates)]
+
+def convert(wild):
+    locations = {}
+    for group in wild["wild_encounter_groups"]:
+        if not group.get("for_maps"):
+            continue
+        for header in group["encounters"]:
+            loc_id, name = location(header["map"])
+            # Maps like Altering Cave list a header per event; the first is the one without an event
+            if loc_id in locations:
+                continue
+            loc = {"name": name}
+            if "land_mons" in header:
+                loc["grass"] = slots(header["land_mons"]["mons"], "grass")
+            if "water_mons" in header:
+                loc["surf"] = slots(header["water_mons"]["mons"], "surf")
+            if "rock_smash_mons" in header:
+                loc["rockSmash"] = slots(header["rock_smash_mons"]["mons"], "rockSmash")
+            if "fishing_mons" in header:
+                for rod, indices in RODS.items():
+                    loc[rod] = slots(header["fishing_mons"]["mons"], rod, indices)
+            locations[loc_id] = loc
+    return locations
+
+def overlay(locations, base):
+    """Keep only the tables that differ from the base game's"""
+    changed = {}
+    for loc_id, loc in locations.items():
+        base_loc = base.get(loc_id, {})
+        tables = {method: table for method, table in loc.items() if method != "name" and base_loc.get(method) != table}
+        if tables:
+            changed[loc_id] = {"name": loc["name"], **tables}
+    return changed
+
+def main():
+    parser = argparse.ArgumentParser(description=__doc__)
+    parser.add_argument("source", nargs="?", help="local wild_encounters.json (default: fetch pokeemerald's)")
+    parser.add_argument("--game", default="emerald")
+    parser.add_argument("--name", default="Pokemon Emerald")
+    parser.add_argument("--base", help="base game to write an overlay against, e.g. emerald")
+    parser.add_argument("--out", help="output path (default: data/encounters/<game>.json)")
+    args = parser.parse_args()
+
+    if args.source:
+        with open(args.source) as f:
+            wild = json.load(f)
+    else:
+        wild = json.loads(fetch_url(WILD_ENCOUNTERS_URL))
+
+    locations = convert(wild)
+    table = {"game": args.game, "name": args.name}
+    if args.base:
+        with open(f"data/encounters/{args.base}.json") as f:
+            base = json.load(f)
+        table["base"] = args.base
+        locations = overlay(locations, base["locations"])
+    table["locations"] = locations
+
+    out = args.out or f"data/encounters/{args.game}.json"
+    with open(out, "w") as f:
+        json.dump(table, f, indent=2, ensure_ascii=False)
+        f.write("\n")
+    print(f"Wrote {len(locations)} locations to {out}")
+
+if __name__ == "__main__":
+    main()
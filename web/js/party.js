@@ -1,15 +1,67 @@
 function partyApp() {
     return {
         party: [],
-        boxes: [], // 14 boxes, each an array of Pokemon
+        boxes: [], // 12-18 boxes depending on the generation, each an array of Pokemon
         bag: null, // Bag pockets with items
         fileName: '',
         lastFile: null,
         error: '',
+        warning: '', // Save slot integrity problems from the last parse
         loading: false,
+        live: false, // True while the server is pushing the watched save file
 
         init() {
             this.loadState();
+            this.connectLive();
+        },
+
+        // Subscribe to the server's save watcher (started with -watch) so the party
+        // refreshes itself every time the emulator writes the save file
+        connectLive() {
+            if (!window.EventSource) return;
+
+            const source = new EventSource('/api/live/events');
+            source.addEventListener('save', (event) => {
+                const result = JSON.parse(event.data);
+                this.live = true;
+                this.error = '';
+                this.fileName = result.file;
+                this.lastFile = null;
+                this.applyResult(result);
+            });
+            source.addEventListener('parse-error', (event) => {
+                const result = JSON.parse(event.data);
+                this.error = 'Failed to parse watched save file: ' + result.error;
+            });
+            source.addEventListener('error', () => {
+                // The server isn't watching a file, or went away
+                if (source.readyState === EventSource.CLOSED) {
+                    this.live = false;
+                }
+            });
+        },
+
+        applyResult(result) {
+            this.party = result.party || [];
+            this.boxes = result.boxes || [];
+            this.bag = result.bag || null;
+            this.warning = this.integrityWarning(result.integrity);
+            if (this.party.length > 0) {
+                this.saveState();
+            }
+        },
+
+        // Describe a corrupt save slot so damaged data isn't shown silently
+        integrityWarning(integrity) {
+            if (!integrity) return '';
+            const used = integrity.slots.find(slot => slot.slot === integrity.usedSlot);
+            if (!integrity.valid) {
+                return 'Save slot ' + integrity.usedSlot + ' is corrupt (' + used.problems.join(', ') + '); some data may be wrong.';
+            }
+            if (integrity.fellBack) {
+                return 'The newest save slot is corrupt, so the previous save (slot ' + integrity.usedSlot + ') was loaded.';
+            }
+            return '';
         },
 
         loadState() {
@@ -96,9 +148,7 @@ function partyApp() {
 
                 const result = await response.json();
                 console.log('Parsed save data:', result);
-                this.party = result.party || [];
-                this.boxes = result.boxes || [];
-                this.bag = result.bag || null;
+                this.applyResult(result);
 
                 // Count total box Pokemon
                 const boxCount = this.boxes.reduce((sum, box) => sum + (box ? box.length : 0), 0);
@@ -112,8 +162,6 @@ function partyApp() {
 
                 if (this.party.length === 0) {
                     this.error = 'No party Pokemon found in save file.';
-                } else {
-                    this.saveState();
                 }
             } catch (e) {
                 console.error('Failed to parse save file:', e);
@@ -123,11 +171,21 @@ function partyApp() {
             }
         },
 
-        getSpriteUrl(species) {
+        getSpriteUrl(species, shiny) {
             if (!species) return '';
             // Convert species name to sprite filename (lowercase, no spaces/special chars)
             const spriteId = species.toLowerCase().replace(/[^a-z0-9]/g, '');
-            return `https://play.pokemonshowdown.com/sprites/gen5/${spriteId}.png`;
+            const folder = shiny ? 'gen5-shiny' : 'gen5';
+            return `https://play.pokemonshowdown.com/sprites/${folder}/${spriteId}.png`;
+        },
+
+        // Gender symbol and shiny star shown after the name
+        getMarks(pokemon) {
+            const marks = [];
+            if (pokemon.gender === 'M') marks.push('♂');
+            if (pokemon.gender === 'F') marks.push('♀');
+            if (pokemon.traits && pokemon.traits.shiny) marks.push('★');
+            return marks.join(' ');
         },
 
         handleSpriteError(event) {
@@ -135,6 +193,22 @@ function partyApp() {
             event.target.src = 'https://play.pokemonshowdown.com/sprites/gen5/0.png';
         },
 
+        getNameTooltip(pokemon) {
+            let tooltip = 'Friendship: ' + pokemon.friendship;
+            const origin = pokemon.origin;
+            if (pokemon.traits && pokemon.traits.unownLetter) tooltip += ' · Form: ' + pokemon.traits.unownLetter;
+            if (pokemon.traits && pokemon.traits.wurmpleEvolution) tooltip += ' · Evolves into ' + pokemon.traits.wurmpleEvolution;
+            if (origin) {
+                tooltip += origin.hatched
+                    ? ' · Hatched at ' + origin.metLocation
+                    : ' · Met at ' + origin.metLocation + ' (Lv. ' + origin.metLevel + ')';
+                if (origin.ball) tooltip += ' in a ' + origin.ball;
+                tooltip += ' · OT: ' + origin.otName;
+                if (origin.pokerus !== 'none') tooltip += ' · Pokérus: ' + origin.pokerus;
+            }
+            return tooltip;
+        },
+
         getNatureTooltip(natureEffect) {
             if (!natureEffect || (!natureEffect.plus && !natureEffect.minus)) {
                 return 'Neutral nature (no stat changes)';